@@ -0,0 +1,104 @@
+package admin
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/logging"
+	"github.com/geometry-labs/icon-blocks/metrics"
+)
+
+// Start - serve the admin endpoints on the admin port
+// NOTE disabled when ADMIN_TOKEN is not set
+func Start() {
+	if config.Config.AdminToken == "" {
+		zap.S().Info("Admin: ADMIN_TOKEN not set, admin server disabled")
+		return
+	}
+
+	handler := authMiddleware(config.Config.AdminToken, newMux())
+
+	go func() {
+		err := http.ListenAndServe(":"+config.Config.AdminPort, handler)
+		if err != nil {
+			zap.S().Warn("Admin: server stopped: ", err.Error())
+		}
+	}()
+	zap.S().Info("Started Admin:", config.Config.AdminPort)
+}
+
+// newMux - admin routes
+// NOTE own mux, the default mux is served without auth on the health and metrics ports
+func newMux() *http.ServeMux {
+	mux := http.NewServeMux()
+
+	// Logging
+	mux.HandleFunc("/log-level", logLevelHandler)
+
+	// Runtime
+	mux.HandleFunc("/config", configHandler)
+	mux.HandleFunc("/loaders", loadersHandler)
+
+	// Kafka jobs
+	mux.HandleFunc("/jobs", jobsHandler)
+	mux.HandleFunc("/jobs/", jobHandler)
+
+	// Profiles
+	mux.HandleFunc("/debug/pprof/", profileHandler)
+	mux.HandleFunc("/debug/pprof/profile", cpuProfileHandler)
+	mux.HandleFunc("/debug/pprof/trace", traceHandler)
+	mux.HandleFunc("/dump/goroutines", goroutineDumpHandler)
+	mux.HandleFunc("/dump/heap", heapDumpHandler)
+
+	return mux
+}
+
+// authMiddleware - reject requests without the admin token as a bearer token
+func authMiddleware(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		requestToken := strings.TrimPrefix(authorization, "Bearer ")
+
+		if requestToken == authorization || subtle.ConstantTimeCompare([]byte(requestToken), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// logLevelHandler - GET or PUT {"level":"debug"} the level of the global logger
+func logLevelHandler(w http.ResponseWriter, r *http.Request) {
+	level := logging.Level()
+	level.ServeHTTP(w, r)
+
+	if r.Method == http.MethodPut {
+		zap.S().Info("Admin: log level is now ", level.String())
+	}
+}
+
+// configHandler - runtime config with secrets redacted
+func configHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, config.Redacted())
+}
+
+// loadersHandler - rows waiting in each loader channel
+func loadersHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, metrics.LoaderChannelDepths())
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(body)
+	if err != nil {
+		zap.S().Warn("Admin: unable to write response: ", err.Error())
+	}
+}
@@ -0,0 +1,98 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/geometry-labs/icon-blocks/logging"
+	"github.com/geometry-labs/icon-blocks/metrics"
+)
+
+func serve(handler http.Handler, method string, path string, token string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+
+	return resp
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := authMiddleware("secret", newMux())
+
+	// No token
+	assert.Equal(http.StatusUnauthorized, serve(handler, "GET", "/loaders", "", "").Code)
+
+	// Wrong token
+	assert.Equal(http.StatusUnauthorized, serve(handler, "GET", "/loaders", "wrong", "").Code)
+
+	// Token without the bearer scheme
+	req := httptest.NewRequest("GET", "/loaders", nil)
+	req.Header.Set("Authorization", "secret")
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(http.StatusUnauthorized, resp.Code)
+
+	// Token
+	assert.Equal(http.StatusOK, serve(handler, "GET", "/loaders", "secret", "").Code)
+}
+
+func TestLogLevelHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := newMux()
+	logging.Level().SetLevel(zapcore.InfoLevel)
+
+	resp := serve(handler, "PUT", "/log-level", "", `{"level":"debug"}`)
+	assert.Equal(http.StatusOK, resp.Code)
+	assert.Equal(zapcore.DebugLevel, logging.Level().Level())
+
+	// Unknown level is rejected
+	resp = serve(handler, "PUT", "/log-level", "", `{"level":"loud"}`)
+	assert.Equal(http.StatusBadRequest, resp.Code)
+	assert.Equal(zapcore.DebugLevel, logging.Level().Level())
+}
+
+func TestLoadersHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	metrics.RegisterLoaderChannelDepth("admin_test", func() int { return 3 })
+
+	resp := serve(newMux(), "GET", "/loaders", "", "")
+	assert.Equal(http.StatusOK, resp.Code)
+
+	depths := map[string]int{}
+	assert.Nil(json.Unmarshal(resp.Body.Bytes(), &depths))
+	assert.Equal(3, depths["admin_test"])
+}
+
+func TestProfileHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := newMux()
+
+	// Index
+	resp := serve(handler, "GET", "/debug/pprof/", "", "")
+	assert.Equal(http.StatusOK, resp.Code)
+	assert.Contains(resp.Body.String(), "goroutine")
+
+	// Named profile
+	resp = serve(handler, "GET", "/debug/pprof/goroutine?debug=1", "", "")
+	assert.Equal(http.StatusOK, resp.Code)
+	assert.Contains(resp.Body.String(), "goroutine profile")
+
+	// Unknown profile
+	resp = serve(handler, "GET", "/debug/pprof/unknown", "", "")
+	assert.Equal(http.StatusNotFound, resp.Code)
+}
@@ -0,0 +1,96 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-blocks/kafka"
+)
+
+// createJobRequest - body of POST /jobs
+type createJobRequest struct {
+	JobID string `json:"job_id"`
+	kafka.JobOptions
+}
+
+// jobsHandler - GET lists the kafka jobs, POST {"job_id":"...","topics":[...],"stop_block_number":0} creates one
+// NOTE topics default to the consumer topics, stop offsets are the current high water marks
+func jobsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		jobs, err := kafka.ListJobs(r.Context())
+		if err != nil {
+			writeJobError(w, err)
+			return
+		}
+
+		writeJSON(w, jobs)
+	case http.MethodPost:
+		request := &createJobRequest{}
+		err := json.NewDecoder(r.Body).Decode(request)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		job, err := kafka.CreateJob(r.Context(), request.JobID, request.JobOptions)
+		if err != nil {
+			writeJobError(w, err)
+			return
+		}
+
+		zap.S().Info("Admin: created kafka job ", job.JobID)
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, job)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// jobHandler - GET inspects a kafka job, DELETE cancels it
+// e.g. /jobs/backfill-1
+func jobHandler(w http.ResponseWriter, r *http.Request) {
+	jobID := strings.TrimPrefix(r.URL.Path, "/jobs/")
+
+	switch r.Method {
+	case http.MethodGet:
+		job, err := kafka.GetJob(r.Context(), jobID)
+		if err != nil {
+			writeJobError(w, err)
+			return
+		}
+
+		writeJSON(w, job)
+	case http.MethodDelete:
+		job, err := kafka.CancelJob(r.Context(), jobID)
+		if err != nil {
+			writeJobError(w, err)
+			return
+		}
+
+		zap.S().Info("Admin: cancelled kafka job ", job.JobID)
+		writeJSON(w, job)
+	default:
+		w.Header().Set("Allow", "GET, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func writeJobError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, kafka.ErrJobNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, kafka.ErrInvalidJobID):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, kafka.ErrJobExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		zap.S().Warn("Admin: kafka job error: ", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
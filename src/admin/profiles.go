@@ -0,0 +1,151 @@
+package admin
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"runtime/pprof"
+	"runtime/trace"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// NOTE net/http/pprof is not imported, it registers its handlers on the default mux
+
+// defaultProfileSeconds - duration of cpu profiles and traces without a seconds parameter
+const defaultProfileSeconds = 30
+
+// profileHandler - named runtime profile, or the list of profiles
+// e.g. /debug/pprof/heap, /debug/pprof/goroutine?debug=1
+func profileHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/debug/pprof/")
+
+	// Index
+	if name == "" {
+		names := []string{"profile", "trace"}
+		for _, profile := range pprof.Profiles() {
+			names = append(names, profile.Name())
+		}
+		sort.Strings(names)
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprintln(w, strings.Join(names, "\n"))
+		return
+	}
+
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		http.Error(w, "unknown profile "+name, http.StatusNotFound)
+		return
+	}
+
+	debugLevel, _ := strconv.Atoi(r.URL.Query().Get("debug"))
+	if debugLevel > 0 {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	} else {
+		setAttachment(w, name)
+	}
+
+	err := profile.WriteTo(w, debugLevel)
+	if err != nil {
+		zap.S().Warn("Admin: unable to write profile ", name, ": ", err.Error())
+	}
+}
+
+// cpuProfileHandler - cpu profile over ?seconds=
+func cpuProfileHandler(w http.ResponseWriter, r *http.Request) {
+	duration := profileDuration(r)
+
+	setAttachment(w, "profile")
+	err := pprof.StartCPUProfile(w)
+	if err != nil {
+		w.Header().Del("Content-Disposition")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sleep(r, duration)
+	pprof.StopCPUProfile()
+}
+
+// traceHandler - execution trace over ?seconds=
+func traceHandler(w http.ResponseWriter, r *http.Request) {
+	duration := profileDuration(r)
+
+	setAttachment(w, "trace")
+	err := trace.Start(w)
+	if err != nil {
+		w.Header().Del("Content-Disposition")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sleep(r, duration)
+	trace.Stop()
+}
+
+// goroutineDumpHandler - stack of every go routine
+func goroutineDumpHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	err := pprof.Lookup("goroutine").WriteTo(w, 2)
+	if err != nil {
+		zap.S().Warn("Admin: unable to write goroutine dump: ", err.Error())
+	}
+}
+
+// heapDumpHandler - full heap dump, readable with the go heap dump tools
+// NOTE the world is stopped while the dump is written
+func heapDumpHandler(w http.ResponseWriter, r *http.Request) {
+	file, err := ioutil.TempFile("", "heapdump")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	zap.S().Info("Admin: writing heap dump")
+	debug.WriteHeapDump(file.Fd())
+
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	setAttachment(w, "heapdump")
+	_, err = io.Copy(w, file)
+	if err != nil {
+		zap.S().Warn("Admin: unable to write heap dump: ", err.Error())
+	}
+}
+
+func profileDuration(r *http.Request) time.Duration {
+	seconds, err := strconv.Atoi(r.URL.Query().Get("seconds"))
+	if err != nil || seconds <= 0 {
+		seconds = defaultProfileSeconds
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// sleep - wait for duration or until the client goes away
+func sleep(r *http.Request, duration time.Duration) {
+	select {
+	case <-time.After(duration):
+	case <-r.Context().Done():
+	}
+}
+
+func setAttachment(w http.ResponseWriter, name string) {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+name+`"`)
+}
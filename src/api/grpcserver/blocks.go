@@ -0,0 +1,163 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-blocks/api/routes/stream"
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/models"
+	"github.com/geometry-labs/icon-blocks/redis"
+)
+
+// blocksServer - implementation of models.BlocksServiceServer
+type blocksServer struct {
+	models.UnimplementedBlocksServiceServer
+}
+
+// GetBlock - block details by number
+func (s *blocksServer) GetBlock(ctx context.Context, req *models.GetBlockRequest) (*models.Block, error) {
+	block, err := crud.GetBlockModel().SelectOne(ctx, req.Number)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.NotFound, "no block found")
+	} else if err != nil {
+		zap.S().Warn("gRPC GetBlock: ", err.Error())
+		return nil, queryError("could not retrieve block", err)
+	}
+
+	return block, nil
+}
+
+// ListBlocks - historical blocks
+func (s *blocksServer) ListBlocks(ctx context.Context, req *models.ListBlocksRequest) (*models.ListBlocksResponse, error) {
+
+	// Default params
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = 25
+	}
+	sort := req.Sort
+	if sort != "desc" && sort != "asc" {
+		sort = "desc"
+	}
+
+	// Check params
+	if err := checkListBlocksRequest(req, limit); err != nil {
+		return nil, err
+	}
+
+	blocks, err := crud.GetBlockModel().SelectMany(
+		ctx,
+		limit,
+		int(req.Skip),
+		req.Number,
+		req.StartNumber,
+		req.EndNumber,
+		req.Hash,
+		req.CreatedBy,
+		sort,
+	)
+	if err != nil {
+		zap.S().Warn("gRPC ListBlocks: ", err.Error())
+		return nil, queryError("could not retrieve blocks", err)
+	}
+
+	// Total count
+	counter, err := crud.GetBlockCountModel().SelectCount(ctx, "block")
+	if err != nil {
+		counter = 0
+		zap.S().Warn("Could not retrieve block count: ", err.Error())
+	}
+
+	res := &models.ListBlocksResponse{
+		Blocks:     make([]*models.BlockAPIList, len(*blocks)),
+		TotalCount: counter,
+	}
+	for i := range *blocks {
+		res.Blocks[i] = &(*blocks)[i]
+	}
+
+	return res, nil
+}
+
+// StreamBlocks - send new blocks from the redis broadcaster until the client disconnects
+func (s *blocksServer) StreamBlocks(req *models.StreamBlocksRequest, srv models.BlocksService_StreamBlocksServer) error {
+	stream.AddConnection()
+	defer stream.ConnectionDone()
+
+	filter := &stream.BlockFilter{
+		MinTransactionCount: req.MinTransactionCount,
+	}
+
+	// Add broadcaster
+	msgChan := redis.GetBroadcaster().NewBroadcastChannel()
+	broadcasterID := redis.GetBroadcaster().AddBroadcastChannel(msgChan)
+	defer func() {
+		// Remove broadcaster
+		redis.GetBroadcaster().RemoveBroadcastChannel(broadcasterID)
+	}()
+
+	for {
+		select {
+		case msg, ok := <-msgChan:
+			if !ok {
+				// Removed by broadcaster
+				return status.Error(codes.ResourceExhausted, "slow consumer")
+			}
+
+			block := &models.BlockWebsocket{}
+			err := json.Unmarshal(msg, block)
+			if err != nil {
+				zap.S().Warn("gRPC StreamBlocks: unable to decode message: ", err.Error())
+				continue
+			}
+
+			if !filter.Match(block) {
+				continue
+			}
+
+			err = srv.Send(block)
+			if err != nil {
+				return err
+			}
+		case <-srv.Context().Done():
+			return nil
+		case <-stream.ShutdownChan():
+			return status.Error(codes.Unavailable, "server shutting down")
+		}
+	}
+}
+
+// checkListBlocksRequest - InvalidArgument status for the first invalid param
+// NOTE end_number 0 is no upper bound
+func checkListBlocksRequest(req *models.ListBlocksRequest, limit int) error {
+	if limit > config.Config.MaxPageSize {
+		return status.Error(codes.InvalidArgument, "invalid limit")
+	}
+	if int(req.Skip) > config.Config.MaxPageSkip {
+		return status.Error(codes.InvalidArgument, "invalid skip")
+	}
+	if req.EndNumber != 0 && req.EndNumber < req.StartNumber {
+		return status.Error(codes.InvalidArgument, "end_number is less than start_number")
+	}
+
+	return nil
+}
+
+// queryError - status for a failed crud read
+func queryError(message string, err error) error {
+	if errors.Is(err, crud.ErrQueryRejected) {
+		return status.Error(codes.InvalidArgument, crud.ErrQueryRejected.Error())
+	} else if errors.Is(err, crud.ErrQueryTimeout) {
+		return status.Error(codes.DeadlineExceeded, crud.ErrQueryTimeout.Error())
+	}
+
+	return status.Error(codes.Internal, message)
+}
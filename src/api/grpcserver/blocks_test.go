@@ -0,0 +1,35 @@
+package grpcserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/models"
+)
+
+func TestCheckListBlocksRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.MaxPageSize = 100
+	config.Config.MaxPageSkip = 1000
+
+	// Valid
+	assert.Nil(checkListBlocksRequest(&models.ListBlocksRequest{}, 25))
+	assert.Nil(checkListBlocksRequest(&models.ListBlocksRequest{StartNumber: 10, EndNumber: 20}, 25))
+
+	// Start number only, end number 0 is no upper bound
+	assert.Nil(checkListBlocksRequest(&models.ListBlocksRequest{StartNumber: 10}, 25))
+
+	// Invalid
+	for _, req := range []*models.ListBlocksRequest{
+		{Skip: 1001},
+		{StartNumber: 20, EndNumber: 10},
+	} {
+		assert.Equal(codes.InvalidArgument, status.Code(checkListBlocksRequest(req, 25)))
+	}
+	assert.Equal(codes.InvalidArgument, status.Code(checkListBlocksRequest(&models.ListBlocksRequest{}, 101)))
+}
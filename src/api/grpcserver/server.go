@@ -0,0 +1,59 @@
+package grpcserver
+
+import (
+	"net"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/models"
+)
+
+var server *grpc.Server
+var healthServer *health.Server
+
+// Start - start the gRPC server
+// Serves BlocksService, server reflection and the gRPC health service
+func Start() {
+
+	listener, err := net.Listen("tcp", ":"+config.Config.GrpcPort)
+	if err != nil {
+		zap.S().Fatal("gRPC: unable to listen on port ", config.Config.GrpcPort, ": ", err.Error())
+	}
+
+	server = grpc.NewServer(
+		grpc.UnaryInterceptor(tracingUnaryInterceptor),
+	)
+
+	// Services
+	models.RegisterBlocksServiceServer(server, &blocksServer{})
+
+	// Health
+	healthServer = health.NewServer()
+	healthServer.SetServingStatus(models.BlocksService_ServiceDesc.ServiceName, grpc_health_v1.HealthCheckResponse_SERVING)
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+
+	// Reflection
+	reflection.Register(server)
+
+	go func() {
+		err := server.Serve(listener)
+		if err != nil {
+			zap.S().Warn("gRPC server stopped: ", err.Error())
+		}
+	}()
+
+	zap.S().Info("Started gRPC server:", config.Config.GrpcPort)
+}
+
+// Shutdown - mark the services as not serving and wait for in-flight calls
+// NOTE streams end once stream.Shutdown is called
+func Shutdown() {
+	healthServer.Shutdown()
+
+	server.GracefulStop()
+}
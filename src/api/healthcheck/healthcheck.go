@@ -1,46 +1,57 @@
 package healthcheck
 
 import (
-	"net/http"
+	"context"
 	"net/url"
 	"time"
 
-	"github.com/geometry-labs/icon-blocks/config"
-	"go.uber.org/zap"
-
-	"github.com/InVisionApp/go-health/v2"
 	"github.com/InVisionApp/go-health/v2/checkers"
-	"github.com/InVisionApp/go-health/v2/handlers"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/probes"
+	"github.com/geometry-labs/icon-blocks/redis"
 )
 
 // Start - start health server
 func Start() {
-	// create a new health instance
-	h := health.New()
 
-	// create a couple of checks
+	// API server
 	blocksCheckerURL, _ := url.Parse("http://localhost:" + config.Config.Port + "/version")
 	blocksChecker, _ := checkers.NewHTTP(&checkers.HTTPConfig{
 		URL: blocksCheckerURL,
 	})
 
-	// Add the checks to the health instance
-	h.AddChecks([]*health.Config{
+	probes.Start([]probes.Check{
 		{
 			Name:     "blocks-rest-check",
 			Checker:  blocksChecker,
-			Interval: time.Duration(config.Config.HealthPollingInterval) * time.Second,
-			Fatal:    true,
+			Severity: probes.SeverityLiveness,
+		},
+		{
+			Name:     "postgres",
+			Checker:  probes.CheckerFunc(crud.Ping),
+			Severity: probes.SeverityReadiness,
+		},
+		{
+			Name: "redis",
+			Checker: probes.CheckerFunc(func(ctx context.Context) error {
+				return redis.GetRedisClient().Ping(ctx)
+			}),
+			Severity: probes.SeverityReadiness,
+		},
+		{
+			// NOTE websocket and event stream clients stop receiving blocks without it
+			Name: "redis-subscription",
+			Checker: probes.CheckerFunc(func(ctx context.Context) error {
+				return redis.GetRedisClient().PingSubscription(ctx)
+			}),
+			Severity: probes.SeverityLiveness,
+		},
+		{
+			Name:     "freshness",
+			Checker:  probes.Freshness(time.Duration(config.Config.HealthFreshnessMaxAge) * time.Second),
+			Severity: probes.SeverityInfo,
 		},
 	})
-
-	//  Start the healthcheck process
-	if err := h.Start(); err != nil {
-		zap.S().Fatalf("Unable to start healthcheck: %v", err)
-	}
-
-	// Define a healthcheck endpoint and use the built-in JSON handler
-	http.HandleFunc(config.Config.HealthPrefix, handlers.NewJSONHandlerFunc(h, nil))
-	go http.ListenAndServe(":"+config.Config.HealthPort, nil)
-	zap.S().Info("Started Healthcheck:", config.Config.HealthPort)
 }
@@ -3,6 +3,8 @@ package main
 import (
 	"log"
 
+	"github.com/geometry-labs/icon-blocks/admin"
+	"github.com/geometry-labs/icon-blocks/api/grpcserver"
 	"github.com/geometry-labs/icon-blocks/api/healthcheck"
 	"github.com/geometry-labs/icon-blocks/api/routes"
 	"github.com/geometry-labs/icon-blocks/config"
@@ -11,6 +13,7 @@ import (
 	"github.com/geometry-labs/icon-blocks/metrics"
 	_ "github.com/geometry-labs/icon-blocks/models" // for swagger docs
 	"github.com/geometry-labs/icon-blocks/redis"
+	"github.com/geometry-labs/icon-blocks/tracing"
 )
 
 func main() {
@@ -19,22 +22,44 @@ func main() {
 	logging.Init()
 	log.Printf("Main: Starting logging with level %s", config.Config.LogLevel)
 
+	// Start tracing
+	tracing.Init()
+
 	// Start Prometheus client
 	// Go routine starts in function
 	metrics.Start()
 
+	// Start Admin server
+	// Go routine starts in function
+	admin.Start()
+
 	// Start Redis Client
 	// NOTE: redis is used for websockets
-	redis.GetBroadcaster().Start()
+	for _, channel := range redis.Channels() {
+		redis.GetChannelBroadcaster(channel).Start()
+	}
 	redis.GetRedisClient().StartSubscriber()
 
 	// Start API server
 	// Go routine starts in function
 	routes.Start()
 
+	// Start gRPC server
+	// Go routine starts in function
+	grpcserver.Start()
+
 	// Start Health server
 	// Go routine starts in function
 	healthcheck.Start()
 
 	global.WaitShutdownSig()
+
+	// Send close frames to websocket clients
+	routes.Shutdown()
+
+	// Stop gRPC server
+	grpcserver.Shutdown()
+
+	// Flush spans
+	tracing.Shutdown()
 }
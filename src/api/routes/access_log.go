@@ -0,0 +1,82 @@
+package routes
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/logging"
+)
+
+var accessLogger *zap.Logger
+var accessLoggerOnce sync.Once
+
+// getAccessLogger - global logger sampled per message
+// NOTE built lazily so it wraps the logger set by logging.Init
+func getAccessLogger() *zap.Logger {
+	accessLoggerOnce.Do(func() {
+		accessLogger = zap.L().WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewSamplerWithOptions(
+				core,
+				time.Second,
+				config.Config.AccessLogSampleInitial,
+				config.Config.AccessLogSampleThereafter,
+			)
+		}))
+	})
+
+	return accessLogger
+}
+
+// accessLogMiddleware - attach the request ID to the request context and log every response
+// NOTE must run after the request ID middleware
+func accessLogMiddleware(c *fiber.Ctx) error {
+	start := time.Now()
+
+	// NOTE cancelled once the handler returns so no query outlives its request
+	requestID, _ := c.Locals("requestid").(string)
+	ctx, cancel := context.WithCancel(logging.WithRequestID(c.UserContext(), requestID))
+	defer cancel()
+	c.SetUserContext(ctx)
+
+	err := c.Next()
+	if err != nil {
+		// Write the error response now so its status is logged
+		if handlerErr := c.App().Config().ErrorHandler(c, err); handlerErr != nil {
+			c.Status(fiber.StatusInternalServerError)
+		}
+		err = nil
+	}
+
+	// NOTE streamed bodies are still being written, reading them would block
+	bytes := -1
+	if !c.Response().IsBodyStream() {
+		bytes = len(c.Response().Body())
+	}
+
+	status := c.Response().StatusCode()
+	fields := []zap.Field{
+		zap.String("method", c.Method()),
+		zap.String("path", c.Path()),
+		zap.Int("status", status),
+		zap.Duration("latency", time.Since(start)),
+		zap.Int("bytes", bytes),
+		zap.String("ip", c.IP()),
+		zap.String("query", string(c.Request().URI().QueryString())),
+		zap.String("request_id", requestID),
+	}
+
+	// Server errors are never sampled
+	if status >= fiber.StatusInternalServerError {
+		zap.L().Error("Access", fields...)
+	} else {
+		getAccessLogger().Info("Access", fields...)
+	}
+
+	return err
+}
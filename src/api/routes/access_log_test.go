@@ -0,0 +1,50 @@
+package routes
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-blocks/api/routes/apierror"
+	"github.com/geometry-labs/icon-blocks/logging"
+)
+
+func TestAccessLogMiddleware(t *testing.T) {
+	assert := assert.New(t)
+
+	app := fiber.New(fiber.Config{ErrorHandler: apierror.Handler})
+	app.Use(requestid.New())
+	app.Use(accessLogMiddleware)
+
+	var requestCtx context.Context
+	app.Get("/request-id", func(c *fiber.Ctx) error {
+		requestCtx = c.UserContext()
+		return c.SendString(logging.RequestIDFromContext(c.UserContext()))
+	})
+	app.Get("/not-found", func(c *fiber.Ctx) error {
+		return apierror.NotFound("no block found")
+	})
+
+	// Inbound request ID is passed to handlers
+	req := httptest.NewRequest("GET", "/request-id", nil)
+	req.Header.Set("X-Request-ID", "c5a8e0b2")
+	resp, err := app.Test(req)
+	assert.Equal(nil, err)
+	assert.Equal("c5a8e0b2", resp.Header.Get("X-Request-ID"))
+
+	body := make([]byte, 8)
+	n, _ := resp.Body.Read(body)
+	assert.Equal("c5a8e0b2", string(body[:n]))
+
+	// Request context is cancelled once the handler returns
+	assert.Equal(context.Canceled, requestCtx.Err())
+
+	// Handler errors are written before logging
+	resp, err = app.Test(httptest.NewRequest("GET", "/not-found", nil))
+	assert.Equal(nil, err)
+	assert.Equal(fiber.StatusNotFound, resp.StatusCode)
+}
@@ -3,34 +3,56 @@ package routes
 import (
 	"encoding/json"
 	"strings"
+	"time"
 
 	swagger "github.com/arsmn/fiber-swagger/v2"
 	fiber "github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/compress"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"go.uber.org/zap"
 
 	_ "github.com/geometry-labs/icon-blocks/api/docs" // import for swagger docs
+	"github.com/geometry-labs/icon-blocks/api/routes/apierror"
+	"github.com/geometry-labs/icon-blocks/api/routes/gql"
+	"github.com/geometry-labs/icon-blocks/api/routes/jsonrpc"
+	"github.com/geometry-labs/icon-blocks/api/routes/ratelimit"
 	"github.com/geometry-labs/icon-blocks/api/routes/rest"
+	"github.com/geometry-labs/icon-blocks/api/routes/sse"
+	"github.com/geometry-labs/icon-blocks/api/routes/stream"
 	"github.com/geometry-labs/icon-blocks/api/routes/ws"
 	"github.com/geometry-labs/icon-blocks/config"
 	"github.com/geometry-labs/icon-blocks/global"
 )
 
+var app *fiber.App
+
 // @title Go api template docs
 // @version 2.0
 // @description This is a sample server server.
 func Start() {
 
-	app := fiber.New()
+	app = fiber.New(fiber.Config{
+		// Write every error as an error envelope
+		ErrorHandler: apierror.Handler,
+
+		// Client IP from the proxy header, only for trusted proxies
+		ProxyHeader:             config.Config.ProxyHeader,
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          config.Config.TrustedProxies,
+	})
+
+	// Request ID middleware
+	// NOTE keeps X-Request-ID when set by the client
+	app.Use(requestid.New())
 
 	// Logging middleware
-	app.Use(func(c *fiber.Ctx) error {
-		zap.S().Info(c.Method(), " ", c.Path())
+	// NOTE passes the request ID to handlers through the user context
+	app.Use(accessLogMiddleware)
 
-		// Go to next middleware:
-		return c.Next()
-	})
+	// Tracing middleware
+	// NOTE after the logging middleware so spans are ended before the access log
+	app.Use(tracingMiddleware)
 
 	// CORS Middleware
 	app.Use(cors.New(cors.Config{
@@ -45,7 +67,8 @@ func Start() {
 		// refer to gofiber/fiber/blob/v1.14.6/middleware/compress.go#L17
 		Level: compress.Level(config.Config.RestCompressLevel),
 		Next: func(c *fiber.Ctx) bool {
-			return strings.Contains(c.Path(), "/docs/")
+			// NOTE event streams are flushed per event and cannot be compressed
+			return strings.Contains(c.Path(), "/docs/") || strings.HasSuffix(c.Path(), "/stream")
 		},
 	}))
 
@@ -56,13 +79,39 @@ func Start() {
 	app.Get("/version", handlerVersion)
 	app.Get("/metadata", handlerMetadata)
 
+	// Rate limit middleware
+	// NOTE added after docs and version handlers so they are not limited
+	app.Use(ratelimit.Handler)
+
 	// Add handlers
+	sse.BlocksAddHandlers(app)
+	gql.BlocksAddHandlers(app)
 	rest.BlocksAddHandlers(app)
 	ws.BlocksAddHandlers(app)
+	jsonrpc.AddHandlers(app)
+
+	// Unmatched routes
+	// NOTE must be added last, fiber does not pass unmatched routes to the error handler
+	app.Use(handlerNotFound)
 
 	go app.Listen(":" + config.Config.Port)
 }
 
+// Shutdown - close websocket and event stream connections and stop the API server
+func Shutdown() {
+	stream.Shutdown(time.Duration(config.Config.WebsocketWriteTimeout) * time.Second)
+
+	err := app.Shutdown()
+	if err != nil {
+		zap.S().Warn("API server shutdown error: ", err.Error())
+	}
+}
+
+// handlerNotFound - error envelope for routes without a handler
+func handlerNotFound(c *fiber.Ctx) error {
+	return apierror.NotFound("cannot " + c.Method() + " " + c.Path())
+}
+
 // Version
 // @Summary Show the status of server.
 // @Description get the status of server.
@@ -0,0 +1,128 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Error codes
+const (
+	CodeInvalidParameters = "invalid_parameters"
+	CodeNotFound          = "not_found"
+	CodeInternalError     = "internal_error"
+	CodeRateLimited       = "rate_limited"
+	CodeInvalidAPIKey     = "invalid_api_key"
+	CodeQueryTooExpensive = "query_too_expensive"
+	CodeQueryTimeout      = "query_timeout"
+)
+
+// Response - error envelope returned by every endpoint
+type Response struct {
+	Error *Error `json:"error"`
+}
+
+// Error - API error
+// Return from a handler and the central error handler writes the envelope
+type Error struct {
+	Status    int          `json:"-"`
+	Code      string       `json:"code" example:"invalid_parameters"`
+	Message   string       `json:"message" example:"invalid query parameters"`
+	Details   []FieldError `json:"details,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+}
+
+// FieldError - reason a single request field is invalid
+type FieldError struct {
+	Field   string `json:"field" example:"limit"`
+	Message string `json:"message" example:"must be between 1 and 100"`
+}
+
+func (e *Error) Error() string {
+	if len(e.Details) == 0 {
+		return e.Message
+	}
+
+	details := make([]string, len(e.Details))
+	for i, detail := range e.Details {
+		details[i] = detail.Field + ": " + detail.Message
+	}
+	return e.Message + " (" + strings.Join(details, ", ") + ")"
+}
+
+// New - error with a status and code
+func New(status int, code string, message string) *Error {
+	return &Error{
+		Status:  status,
+		Code:    code,
+		Message: message,
+	}
+}
+
+// InvalidParameters - 422 error listing every invalid field
+func InvalidParameters(details []FieldError) *Error {
+	return &Error{
+		Status:  fiber.StatusUnprocessableEntity,
+		Code:    CodeInvalidParameters,
+		Message: "invalid query parameters",
+		Details: details,
+	}
+}
+
+// NotFound - 404 error
+func NotFound(message string) *Error {
+	return New(fiber.StatusNotFound, CodeNotFound, message)
+}
+
+// Internal - 500 error
+// NOTE cause is logged, not returned to the client
+func Internal(message string, cause error) *Error {
+	if cause != nil {
+		zap.S().Warn(message, ": ", cause.Error())
+	}
+
+	return New(fiber.StatusInternalServerError, CodeInternalError, message)
+}
+
+// Handler - central fiber error handler
+// Writes every error returned by a handler or middleware as an error envelope
+func Handler(c *fiber.Ctx, err error) error {
+	var apiErr *Error
+	var fiberErr *fiber.Error
+	if errors.As(err, &apiErr) {
+		// Use as is
+	} else if errors.As(err, &fiberErr) {
+		apiErr = New(fiberErr.Code, codeForStatus(fiberErr.Code), fiberErr.Message)
+	} else {
+		apiErr = Internal("internal error", err)
+	}
+
+	// Copy so the returned error is not modified
+	response := *apiErr
+	response.RequestID = requestID(c)
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Status(response.Status).JSON(&Response{Error: &response})
+}
+
+// codeForStatus - snake case status text, e.g. 426 -> upgrade_required
+func codeForStatus(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return fmt.Sprintf("status_%d", status)
+	}
+
+	return strings.ReplaceAll(strings.ToLower(text), " ", "_")
+}
+
+func requestID(c *fiber.Ctx) string {
+	id, _ := c.Locals("requestid").(string)
+	if id == "" {
+		id = string(c.Response().Header.Peek(fiber.HeaderXRequestID))
+	}
+	return id
+}
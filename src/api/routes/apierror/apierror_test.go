@@ -0,0 +1,95 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	app := fiber.New(fiber.Config{
+		ErrorHandler: Handler,
+	})
+	app.Use(requestid.New())
+
+	app.Get("/invalid", func(c *fiber.Ctx) error {
+		return InvalidParameters([]FieldError{
+			{Field: "limit", Message: "must be between 1 and 100"},
+			{Field: "sort", Message: "must be asc or desc"},
+		})
+	})
+	app.Get("/internal", func(c *fiber.Ctx) error {
+		return errors.New("connection refused")
+	})
+	app.Get("/fiber", func(c *fiber.Ctx) error {
+		return fiber.ErrNotFound
+	})
+	app.Use(func(c *fiber.Ctx) error {
+		return NotFound("cannot " + c.Method() + " " + c.Path())
+	})
+
+	// API error
+	req := httptest.NewRequest("GET", "/invalid", nil)
+	req.Header.Set(fiber.HeaderXRequestID, "test-id")
+	resp, err := app.Test(req)
+	assert.Equal(nil, err)
+	assert.Equal(422, resp.StatusCode)
+
+	body, _ := ioutil.ReadAll(resp.Body)
+	response := &Response{}
+	json.Unmarshal(body, response)
+	if !assert.NotNil(response.Error) {
+		return
+	}
+	assert.Equal(CodeInvalidParameters, response.Error.Code)
+	assert.Equal(2, len(response.Error.Details))
+	assert.Equal("sort", response.Error.Details[1].Field)
+	assert.Equal("test-id", response.Error.RequestID)
+
+	// Unknown error is not exposed
+	resp, _ = app.Test(httptest.NewRequest("GET", "/internal", nil))
+	assert.Equal(500, resp.StatusCode)
+
+	body, _ = ioutil.ReadAll(resp.Body)
+	response = &Response{}
+	json.Unmarshal(body, response)
+	if !assert.NotNil(response.Error) {
+		return
+	}
+	assert.Equal(CodeInternalError, response.Error.Code)
+	assert.Equal("internal error", response.Error.Message)
+	assert.NotEqual("", response.Error.RequestID)
+
+	// Fiber error
+	resp, _ = app.Test(httptest.NewRequest("GET", "/fiber", nil))
+	assert.Equal(404, resp.StatusCode)
+
+	body, _ = ioutil.ReadAll(resp.Body)
+	response = &Response{}
+	json.Unmarshal(body, response)
+	if !assert.NotNil(response.Error) {
+		return
+	}
+	assert.Equal(CodeNotFound, response.Error.Code)
+
+	// Unmatched route
+	resp, _ = app.Test(httptest.NewRequest("GET", "/missing", nil))
+	assert.Equal(404, resp.StatusCode)
+
+	body, _ = ioutil.ReadAll(resp.Body)
+	response = &Response{}
+	json.Unmarshal(body, response)
+	if !assert.NotNil(response.Error) {
+		return
+	}
+	assert.Equal(CodeNotFound, response.Error.Code)
+	assert.Equal("cannot GET /missing", response.Error.Message)
+}
@@ -0,0 +1,87 @@
+package gql
+
+import (
+	"encoding/json"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/graphql-go/graphql"
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-blocks/api/routes/apierror"
+	"github.com/geometry-labs/icon-blocks/config"
+)
+
+var schema graphql.Schema
+
+// BlocksAddHandlers - add GraphQL endpoint to fiber router
+// NOTE add before the rest handlers, /graphql is not a block number
+func BlocksAddHandlers(app *fiber.App) {
+
+	var err error
+	schema, err = newSchema()
+	if err != nil {
+		zap.S().Fatal("GraphQL: unable to build schema: ", err.Error())
+	}
+
+	prefix := config.Config.RestPrefix + "/blocks"
+
+	app.Get(prefix+"/graphql", handlerGraphQL)
+	app.Post(prefix+"/graphql", handlerGraphQL)
+}
+
+// Body for handlerGraphQL
+type paramsGraphQL struct {
+	Query         string                 `json:"query" query:"query"`
+	OperationName string                 `json:"operationName" query:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// GraphQL
+// @Summary GraphQL
+// @Description query blocks and their transactions, internal transactions, failed transactions and block times
+// @Tags Blocks
+// @BasePath /api/v1
+// @Accept json
+// @Produce json
+// @Param query query string false "GraphQL query, GET only"
+// @Router /api/v1/blocks/graphql [post]
+// @Success 200 {object} map[string]interface{}
+// @Failure 422 {object} apierror.Response
+func handlerGraphQL(c *fiber.Ctx) error {
+	params := &paramsGraphQL{}
+	if c.Method() == fiber.MethodGet {
+		err := c.QueryParser(params)
+		if err == nil && c.Query("variables") != "" {
+			err = json.Unmarshal([]byte(c.Query("variables")), &params.Variables)
+		}
+		if err != nil {
+			return apierror.New(fiber.StatusUnprocessableEntity, apierror.CodeInvalidParameters, "could not parse query parameters")
+		}
+	} else {
+		err := json.Unmarshal(c.Body(), params)
+		if err != nil {
+			return apierror.New(fiber.StatusUnprocessableEntity, apierror.CodeInvalidParameters, "could not parse body")
+		}
+	}
+
+	// Limits
+	cost, err := measureQuery(params.Query, params.OperationName, params.Variables)
+	if err == nil {
+		err = checkLimits(cost, config.Config.GraphQLMaxDepth, config.Config.GraphQLMaxComplexity)
+	}
+	if err != nil {
+		return apierror.New(fiber.StatusUnprocessableEntity, apierror.CodeInvalidParameters, err.Error())
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  params.Query,
+		VariableValues: params.Variables,
+		OperationName:  params.OperationName,
+		Context:        withLoaders(c.UserContext()),
+	})
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	body, _ := json.Marshal(result)
+	return c.SendString(string(body))
+}
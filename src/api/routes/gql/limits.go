@@ -0,0 +1,143 @@
+package gql
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// queryCost - depth and complexity of a query
+// Complexity counts one per selected field, multiplied by the limit argument of
+// every enclosing list field, e.g. blocks(limit: 10) { number hash } costs 1 + 10*2
+// NOTE child lists (transactions of a block) have no limit argument and count once
+type queryCost struct {
+	depth      int
+	complexity int
+}
+
+// measureQuery - parse the query and measure the selected operation
+func measureQuery(query string, operationName string, variables map[string]interface{}) (*queryCost, error) {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return nil, err
+	}
+
+	var operation *ast.OperationDefinition
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				if operation == nil {
+					operation = definition
+				}
+			}
+		case *ast.FragmentDefinition:
+			fragments[definition.Name.Value] = definition
+		}
+	}
+	if operation == nil {
+		return nil, errors.New("no operation found")
+	}
+
+	m := &measurer{
+		fragments: fragments,
+		variables: variables,
+		visiting:  map[string]bool{},
+	}
+	depth, complexity := m.selectionSet(operation.SelectionSet)
+
+	return &queryCost{
+		depth:      depth,
+		complexity: complexity,
+	}, nil
+}
+
+// checkLimits - error if the query is deeper or more complex than allowed
+func checkLimits(cost *queryCost, maxDepth int, maxComplexity int) error {
+	if cost.depth > maxDepth {
+		return fmt.Errorf("query depth %d exceeds the maximum of %d", cost.depth, maxDepth)
+	}
+	if cost.complexity > maxComplexity {
+		return fmt.Errorf("query complexity %d exceeds the maximum of %d", cost.complexity, maxComplexity)
+	}
+
+	return nil
+}
+
+type measurer struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	visiting  map[string]bool // fragment cycle guard
+}
+
+// selectionSet - returns depth, complexity
+func (m *measurer) selectionSet(selectionSet *ast.SelectionSet) (int, int) {
+	if selectionSet == nil {
+		return 0, 0
+	}
+
+	maxDepth := 0
+	complexity := 0
+	for _, selection := range selectionSet.Selections {
+		depth := 0
+		cost := 0
+
+		switch selection := selection.(type) {
+		case *ast.Field:
+			childDepth, childComplexity := m.selectionSet(selection.SelectionSet)
+			depth = childDepth + 1
+			cost = 1 + m.multiplier(selection)*childComplexity
+		case *ast.InlineFragment:
+			depth, cost = m.selectionSet(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			fragment, ok := m.fragments[name]
+			if !ok || m.visiting[name] {
+				continue
+			}
+
+			m.visiting[name] = true
+			depth, cost = m.selectionSet(fragment.SelectionSet)
+			m.visiting[name] = false
+		}
+
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+		complexity += cost
+	}
+
+	return maxDepth, complexity
+}
+
+// multiplier - limit argument of a list field, 1 otherwise
+func (m *measurer) multiplier(field *ast.Field) int {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "limit" {
+			continue
+		}
+
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			limit, err := strconv.Atoi(value.Value)
+			if err == nil && limit > 0 {
+				return limit
+			}
+		case *ast.Variable:
+			limit, ok := m.variables[value.Name.Value].(float64)
+			if ok && limit > 0 {
+				return int(limit)
+			}
+		}
+	}
+
+	if field.Name.Value == "blocks" {
+		return defaultLimit
+	}
+
+	return 1
+}
@@ -0,0 +1,54 @@
+package gql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasureQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	// Depth
+	cost, err := measureQuery(`{ block(number: 1) { number failed_transactions { transaction_hash } } }`, "", nil)
+	assert.Equal(nil, err)
+	assert.Equal(3, cost.depth)
+	assert.Equal(1+(1+(1+1)), cost.complexity)
+
+	// Limit argument multiplies children
+	cost, err = measureQuery(`{ blocks(limit: 10) { number hash } }`, "", nil)
+	assert.Equal(nil, err)
+	assert.Equal(1+10*2, cost.complexity)
+
+	// Limit from variables
+	cost, err = measureQuery(`query q($limit: Int) { blocks(limit: $limit) { number } }`, "q", map[string]interface{}{"limit": float64(4)})
+	assert.Equal(nil, err)
+	assert.Equal(1+4*1, cost.complexity)
+
+	// Default limit
+	cost, err = measureQuery(`{ blocks { number } }`, "", nil)
+	assert.Equal(nil, err)
+	assert.Equal(1+defaultLimit*1, cost.complexity)
+
+	// Fragments
+	cost, err = measureQuery(`{ block(number: 1) { ...f } } fragment f on Block { number transactions { fee } }`, "", nil)
+	assert.Equal(nil, err)
+	assert.Equal(3, cost.depth)
+	assert.Equal(1+(1+(1+1)), cost.complexity)
+
+	// Fragment cycles do not recurse forever
+	_, err = measureQuery(`{ block(number: 1) { ...a } } fragment a on Block { number ...a }`, "", nil)
+	assert.Equal(nil, err)
+
+	// Invalid
+	_, err = measureQuery(`{ block(`, "", nil)
+	assert.NotEqual(nil, err)
+}
+
+func TestCheckLimits(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(nil, checkLimits(&queryCost{depth: 3, complexity: 10}, 3, 10))
+	assert.NotEqual(nil, checkLimits(&queryCost{depth: 4, complexity: 10}, 3, 10))
+	assert.NotEqual(nil, checkLimits(&queryCost{depth: 3, complexity: 11}, 3, 10))
+}
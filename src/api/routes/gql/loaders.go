@@ -0,0 +1,198 @@
+package gql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/models"
+)
+
+// batchLoader - collect block numbers requested by sibling resolvers and load them with one query
+// NOTE graphql-go resolves every item of a list before calling any thunk,
+// so all numbers of a list are registered before the first thunk runs
+type batchLoader struct {
+	mutex   sync.Mutex
+	batchFn func(numbers []uint32) (map[uint32]interface{}, error)
+	pending []uint32
+	results map[uint32]interface{}
+	errors  map[uint32]error
+}
+
+func newBatchLoader(batchFn func(numbers []uint32) (map[uint32]interface{}, error)) *batchLoader {
+	return &batchLoader{
+		batchFn: batchFn,
+		results: map[uint32]interface{}{},
+		errors:  map[uint32]error{},
+	}
+}
+
+// Load - register number and return a thunk resolving to its value
+func (l *batchLoader) Load(number uint32) func() (interface{}, error) {
+	l.mutex.Lock()
+	l.pending = append(l.pending, number)
+	l.mutex.Unlock()
+
+	return func() (interface{}, error) {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+
+		if len(l.pending) > 0 {
+			l.flush()
+		}
+
+		return l.results[number], l.errors[number]
+	}
+}
+
+// flush - load every pending number not loaded yet
+// NOTE caller must hold the lock
+func (l *batchLoader) flush() {
+	numbers := []uint32{}
+	seen := map[uint32]bool{}
+	for _, number := range l.pending {
+		_, loaded := l.results[number]
+		if loaded || seen[number] {
+			continue
+		}
+
+		seen[number] = true
+		numbers = append(numbers, number)
+	}
+	l.pending = nil
+
+	if len(numbers) == 0 {
+		return
+	}
+
+	results, err := l.batchFn(numbers)
+	for _, number := range numbers {
+		l.results[number] = results[number]
+		l.errors[number] = err
+	}
+}
+
+// loaders - batch loaders for one request
+type loaders struct {
+	transactions         *batchLoader
+	internalTransactions *batchLoader
+	failedTransactions   *batchLoader
+	blockTimes           *batchLoader
+}
+
+type loadersContextKey struct{}
+
+func newLoaders(ctx context.Context) *loaders {
+	return &loaders{
+		transactions:         newBatchLoader(withContext(ctx, loadBlockTransactions)),
+		internalTransactions: newBatchLoader(withContext(ctx, loadBlockInternalTransactions)),
+		failedTransactions:   newBatchLoader(withContext(ctx, loadBlockFailedTransactions)),
+		blockTimes:           newBatchLoader(withContext(ctx, loadBlockTimes)),
+	}
+}
+
+// withContext - bind the request context to a batch query
+func withContext(
+	ctx context.Context,
+	batchFn func(ctx context.Context, numbers []uint32) (map[uint32]interface{}, error),
+) func(numbers []uint32) (map[uint32]interface{}, error) {
+	return func(numbers []uint32) (map[uint32]interface{}, error) {
+		return batchFn(ctx, numbers)
+	}
+}
+
+// withLoaders - attach a new set of loaders to the request context
+func withLoaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loadersContextKey{}, newLoaders(ctx))
+}
+
+func loadersFromContext(ctx context.Context) *loaders {
+	return ctx.Value(loadersContextKey{}).(*loaders)
+}
+
+///////////////////
+// Batch queries //
+///////////////////
+
+func loadBlockTransactions(ctx context.Context, numbers []uint32) (map[uint32]interface{}, error) {
+	rows, err := crud.GetBlockTransactionModel().SelectManyByNumbers(ctx, numbers)
+	if err != nil {
+		return nil, err
+	}
+
+	// Empty list for blocks without rows
+	results := map[uint32][]*models.BlockTransaction{}
+	for _, number := range numbers {
+		results[number] = []*models.BlockTransaction{}
+	}
+	for i := range *rows {
+		row := &(*rows)[i]
+		results[row.Number] = append(results[row.Number], row)
+	}
+
+	values := map[uint32]interface{}{}
+	for number, result := range results {
+		values[number] = result
+	}
+	return values, nil
+}
+
+func loadBlockInternalTransactions(ctx context.Context, numbers []uint32) (map[uint32]interface{}, error) {
+	rows, err := crud.GetBlockInternalTransactionModel().SelectManyByNumbers(ctx, numbers)
+	if err != nil {
+		return nil, err
+	}
+
+	// Empty list for blocks without rows
+	results := map[uint32][]*models.BlockInternalTransaction{}
+	for _, number := range numbers {
+		results[number] = []*models.BlockInternalTransaction{}
+	}
+	for i := range *rows {
+		row := &(*rows)[i]
+		results[row.Number] = append(results[row.Number], row)
+	}
+
+	values := map[uint32]interface{}{}
+	for number, result := range results {
+		values[number] = result
+	}
+	return values, nil
+}
+
+func loadBlockFailedTransactions(ctx context.Context, numbers []uint32) (map[uint32]interface{}, error) {
+	rows, err := crud.GetBlockFailedTransactionModel().SelectManyByNumbers(ctx, numbers)
+	if err != nil {
+		return nil, err
+	}
+
+	// Empty list for blocks without rows
+	results := map[uint32][]*models.BlockFailedTransaction{}
+	for _, number := range numbers {
+		results[number] = []*models.BlockFailedTransaction{}
+	}
+	for i := range *rows {
+		row := &(*rows)[i]
+		results[row.Number] = append(results[row.Number], row)
+	}
+
+	values := map[uint32]interface{}{}
+	for number, result := range results {
+		values[number] = result
+	}
+	return values, nil
+}
+
+func loadBlockTimes(ctx context.Context, numbers []uint32) (map[uint32]interface{}, error) {
+	rows, err := crud.GetBlockTimeModel().SelectManyByNumbers(ctx, numbers)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[uint32]interface{}{}
+	for i := range *rows {
+		row := &(*rows)[i]
+		values[row.Number] = row
+	}
+	return values, nil
+}
@@ -0,0 +1,46 @@
+package gql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchLoader(t *testing.T) {
+	assert := assert.New(t)
+
+	batches := [][]uint32{}
+	loader := newBatchLoader(func(numbers []uint32) (map[uint32]interface{}, error) {
+		batches = append(batches, numbers)
+
+		results := map[uint32]interface{}{}
+		for _, number := range numbers {
+			results[number] = number * 10
+		}
+		return results, nil
+	})
+
+	// Sibling loads are batched into one query
+	thunks := []func() (interface{}, error){
+		loader.Load(1),
+		loader.Load(2),
+		loader.Load(2),
+		loader.Load(3),
+	}
+	for i, expected := range []uint32{10, 20, 20, 30} {
+		value, err := thunks[i]()
+		assert.Equal(nil, err)
+		assert.Equal(expected, value)
+	}
+	assert.Equal([][]uint32{{1, 2, 3}}, batches)
+
+	// Loaded numbers are cached
+	value, _ := loader.Load(1)()
+	assert.Equal(uint32(10), value)
+	assert.Equal(1, len(batches))
+
+	// New numbers start a new batch
+	value, _ = loader.Load(4)()
+	assert.Equal(uint32(40), value)
+	assert.Equal([][]uint32{{1, 2, 3}, {4}}, batches)
+}
@@ -0,0 +1,308 @@
+package gql
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/models"
+)
+
+// Uint64 - scalar for uint64 fields, graphql.Int is limited to 32 bits
+var Uint64 = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Uint64",
+	Description: "unsigned 64 bit integer",
+	Serialize: func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case uint64:
+			return value
+		case *uint64:
+			return *value
+		}
+		return nil
+	},
+	ParseValue: func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case float64:
+			if value < 0 || value > math.MaxUint64 {
+				return nil
+			}
+			return uint64(value)
+		case string:
+			parsed, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return nil
+			}
+			return parsed
+		}
+		return nil
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		switch valueAST := valueAST.(type) {
+		case *ast.IntValue:
+			parsed, err := strconv.ParseUint(valueAST.Value, 10, 64)
+			if err != nil {
+				return nil
+			}
+			return parsed
+		}
+		return nil
+	},
+})
+
+/////////////////
+// Child types //
+/////////////////
+
+var blockTransactionType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "BlockTransaction",
+	Fields: graphql.Fields{
+		"number":           &graphql.Field{Type: graphql.Int},
+		"transaction_hash": &graphql.Field{Type: graphql.String},
+		"amount":           &graphql.Field{Type: graphql.String},
+		"fee":              &graphql.Field{Type: graphql.String},
+	},
+})
+
+var blockInternalTransactionType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "BlockInternalTransaction",
+	Fields: graphql.Fields{
+		"number":           &graphql.Field{Type: graphql.Int},
+		"transaction_hash": &graphql.Field{Type: graphql.String},
+		"log_index":        &graphql.Field{Type: graphql.Int},
+		"amount":           &graphql.Field{Type: graphql.String},
+	},
+})
+
+var blockFailedTransactionType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "BlockFailedTransaction",
+	Fields: graphql.Fields{
+		"number":           &graphql.Field{Type: graphql.Int},
+		"transaction_hash": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var blockTimeType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "BlockTime",
+	Fields: graphql.Fields{
+		"number": &graphql.Field{Type: graphql.Int},
+		"time":   &graphql.Field{Type: Uint64},
+	},
+})
+
+/////////////////
+// Block types //
+/////////////////
+
+var blockType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Block",
+	Fields: withChildFields(graphql.Fields{
+		"signature":                   &graphql.Field{Type: graphql.String},
+		"item_id":                     &graphql.Field{Type: graphql.String},
+		"next_leader":                 &graphql.Field{Type: graphql.String},
+		"transaction_count":           &graphql.Field{Type: graphql.Int},
+		"type":                        &graphql.Field{Type: graphql.String},
+		"version":                     &graphql.Field{Type: graphql.String},
+		"peer_id":                     &graphql.Field{Type: graphql.String},
+		"number":                      &graphql.Field{Type: graphql.Int},
+		"merkle_root_hash":            &graphql.Field{Type: graphql.String},
+		"item_timestamp":              &graphql.Field{Type: graphql.String},
+		"hash":                        &graphql.Field{Type: graphql.String},
+		"parent_hash":                 &graphql.Field{Type: graphql.String},
+		"timestamp":                   &graphql.Field{Type: Uint64},
+		"transaction_fees":            &graphql.Field{Type: graphql.String},
+		"transaction_amount":          &graphql.Field{Type: graphql.String},
+		"internal_transaction_amount": &graphql.Field{Type: graphql.String},
+		"internal_transaction_count":  &graphql.Field{Type: graphql.Int},
+		"failed_transaction_count":    &graphql.Field{Type: graphql.Int},
+		"block_time":                  &graphql.Field{Type: Uint64},
+	}),
+})
+
+// blockListType - fields returned by the blocks list, same as GET /blocks
+var blockListType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "BlockListItem",
+	Fields: withChildFields(graphql.Fields{
+		"transaction_count":  &graphql.Field{Type: graphql.Int},
+		"number":             &graphql.Field{Type: graphql.Int},
+		"hash":               &graphql.Field{Type: graphql.String},
+		"timestamp":          &graphql.Field{Type: Uint64},
+		"transaction_fees":   &graphql.Field{Type: graphql.String},
+		"transaction_amount": &graphql.Field{Type: graphql.String},
+		"peer_id":            &graphql.Field{Type: graphql.String},
+	}),
+})
+
+// withChildFields - add fields resolved through the batch loaders
+func withChildFields(fields graphql.Fields) graphql.Fields {
+	fields["transactions"] = &graphql.Field{
+		Type: graphql.NewList(blockTransactionType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return loadersFromContext(p.Context).transactions.Load(sourceNumber(p.Source)), nil
+		},
+	}
+	fields["internal_transactions"] = &graphql.Field{
+		Type: graphql.NewList(blockInternalTransactionType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return loadersFromContext(p.Context).internalTransactions.Load(sourceNumber(p.Source)), nil
+		},
+	}
+	fields["failed_transactions"] = &graphql.Field{
+		Type: graphql.NewList(blockFailedTransactionType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return loadersFromContext(p.Context).failedTransactions.Load(sourceNumber(p.Source)), nil
+		},
+	}
+	fields["block_time_details"] = &graphql.Field{
+		Type: blockTimeType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return loadersFromContext(p.Context).blockTimes.Load(sourceNumber(p.Source)), nil
+		},
+	}
+
+	return fields
+}
+
+func sourceNumber(source interface{}) uint32 {
+	switch block := source.(type) {
+	case *models.Block:
+		return block.Number
+	case *models.BlockAPIList:
+		return block.Number
+	}
+	return 0
+}
+
+////////////
+// Schema //
+////////////
+
+var queryType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Query",
+	Fields: graphql.Fields{
+		"block": &graphql.Field{
+			Type:        blockType,
+			Description: "block details by number",
+			Args: graphql.FieldConfigArgument{
+				"number": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+			},
+			Resolve: resolveBlock,
+		},
+		"blocks": &graphql.Field{
+			Type:        graphql.NewList(blockListType),
+			Description: "historical blocks, same filters as GET /blocks",
+			Args: graphql.FieldConfigArgument{
+				"limit":        &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultLimit},
+				"skip":         &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+				"number":       &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+				"start_number": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+				"end_number":   &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+				"hash":         &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: ""},
+				"created_by":   &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: ""},
+				"sort":         &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "desc"},
+			},
+			Resolve: resolveBlocks,
+		},
+	},
+})
+
+// newSchema - GraphQL schema for blocks and their child records
+func newSchema() (graphql.Schema, error) {
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query: queryType,
+	})
+}
+
+// defaultLimit - page size when the blocks limit argument is not set
+const defaultLimit = 25
+
+func resolveBlock(p graphql.ResolveParams) (interface{}, error) {
+	number := p.Args["number"].(int)
+	if number < 0 {
+		return nil, errors.New("invalid number")
+	}
+
+	block, err := crud.GetBlockModel().SelectOne(p.Context, uint32(number))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, queryError("could not retrieve block", err)
+	}
+
+	return block, nil
+}
+
+func resolveBlocks(p graphql.ResolveParams) (interface{}, error) {
+	limit := p.Args["limit"].(int)
+	skip := p.Args["skip"].(int)
+	number := p.Args["number"].(int)
+	startNumber := p.Args["start_number"].(int)
+	endNumber := p.Args["end_number"].(int)
+	sort := p.Args["sort"].(string)
+
+	// Check params
+	if err := checkBlocksArgs(limit, skip, number, startNumber, endNumber); err != nil {
+		return nil, err
+	}
+	if sort != "desc" && sort != "asc" {
+		sort = "desc"
+	}
+
+	blocks, err := crud.GetBlockModel().SelectMany(
+		p.Context,
+		limit,
+		skip,
+		uint32(number),
+		uint32(startNumber),
+		uint32(endNumber),
+		p.Args["hash"].(string),
+		p.Args["created_by"].(string),
+		sort,
+	)
+	if err != nil {
+		return nil, queryError("could not retrieve blocks", err)
+	}
+
+	results := make([]*models.BlockAPIList, len(*blocks))
+	for i := range *blocks {
+		results[i] = &(*blocks)[i]
+	}
+
+	return results, nil
+}
+
+// checkBlocksArgs - error for the first invalid blocks argument
+// NOTE end_number 0 is no upper bound
+func checkBlocksArgs(limit int, skip int, number int, startNumber int, endNumber int) error {
+	if limit < 1 || limit > config.Config.MaxPageSize {
+		return errors.New("invalid limit")
+	}
+	if skip < 0 || skip > config.Config.MaxPageSkip {
+		return errors.New("invalid skip")
+	}
+	if number < 0 || startNumber < 0 || endNumber < 0 {
+		return errors.New("invalid number")
+	}
+	if endNumber != 0 && endNumber < startNumber {
+		return errors.New("end_number is less than start_number")
+	}
+
+	return nil
+}
+
+// queryError - error for a failed crud read, without database details
+func queryError(message string, err error) error {
+	if errors.Is(err, crud.ErrQueryRejected) {
+		return crud.ErrQueryRejected
+	} else if errors.Is(err, crud.ErrQueryTimeout) {
+		return crud.ErrQueryTimeout
+	}
+
+	return errors.New(message)
+}
@@ -0,0 +1,29 @@
+package gql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-blocks/config"
+)
+
+func TestCheckBlocksArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.MaxPageSize = 100
+	config.Config.MaxPageSkip = 1000
+
+	// Valid
+	assert.Nil(checkBlocksArgs(25, 0, 0, 0, 0))
+	assert.Nil(checkBlocksArgs(25, 0, 0, 10, 20))
+
+	// Start number only, end number 0 is no upper bound
+	assert.Nil(checkBlocksArgs(25, 0, 0, 10, 0))
+
+	// Invalid
+	assert.EqualError(checkBlocksArgs(0, 0, 0, 0, 0), "invalid limit")
+	assert.EqualError(checkBlocksArgs(25, 1001, 0, 0, 0), "invalid skip")
+	assert.EqualError(checkBlocksArgs(25, 0, -1, 0, 0), "invalid number")
+	assert.EqualError(checkBlocksArgs(25, 0, 0, 20, 10), "end_number is less than start_number")
+}
@@ -0,0 +1,141 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/models"
+)
+
+// iconBlock - block in the shape returned by ICON nodes
+type iconBlock struct {
+	Version                  string             `json:"version"`
+	PrevBlockHash            string             `json:"prev_block_hash"`
+	MerkleTreeRootHash       string             `json:"merkle_tree_root_hash"`
+	TimeStamp                uint64             `json:"time_stamp"`
+	ConfirmedTransactionList []*iconTransaction `json:"confirmed_transaction_list"`
+	BlockHash                string             `json:"block_hash"`
+	Height                   uint32             `json:"height"`
+	PeerID                   string             `json:"peer_id"`
+	Signature                string             `json:"signature"`
+	NextLeader               string             `json:"next_leader,omitempty"`
+}
+
+// iconTransaction - confirmed transaction
+// NOTE only the fields indexed in block_transactions are set
+type iconTransaction struct {
+	TxHash string `json:"txHash"`
+	Value  string `json:"value,omitempty"`
+}
+
+type paramsGetBlockByHeight struct {
+	Height string `json:"height"`
+}
+
+type paramsGetBlockByHash struct {
+	Hash string `json:"hash"`
+}
+
+func icxGetLastBlock(ctx context.Context, params json.RawMessage) (interface{}, *rpcError) {
+	// NOTE SelectOne returns the latest block for number 0
+	block, err := crud.GetBlockModel().SelectOne(ctx, 0)
+
+	return blockResult(ctx, block, err)
+}
+
+func icxGetBlockByHeight(ctx context.Context, params json.RawMessage) (interface{}, *rpcError) {
+	p := &paramsGetBlockByHeight{}
+	err := json.Unmarshal(params, p)
+	if err != nil || p.Height == "" {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "height required"}
+	}
+
+	height, err := parseHexUint32(p.Height)
+	if err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "invalid height"}
+	}
+	if height == 0 {
+		// SelectOne treats 0 as the latest block
+		return nil, &rpcError{Code: codeNotFound, Message: "genesis block is not indexed"}
+	}
+
+	block, err := crud.GetBlockModel().SelectOne(ctx, height)
+
+	return blockResult(ctx, block, err)
+}
+
+func icxGetBlockByHash(ctx context.Context, params json.RawMessage) (interface{}, *rpcError) {
+	p := &paramsGetBlockByHash{}
+	err := json.Unmarshal(params, p)
+	if err != nil || p.Hash == "" {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "hash required"}
+	}
+
+	// Stored without prefix
+	hash := strings.TrimPrefix(p.Hash, "0x")
+
+	block, err := crud.GetBlockModel().SelectOneByHash(ctx, hash)
+
+	return blockResult(ctx, block, err)
+}
+
+func blockResult(ctx context.Context, block *models.Block, err error) (interface{}, *rpcError) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, &rpcError{Code: codeNotFound, Message: "block not found"}
+	} else if errors.Is(err, crud.ErrQueryTimeout) {
+		return nil, &rpcError{Code: codeSystemError, Message: crud.ErrQueryTimeout.Error()}
+	} else if err != nil {
+		zap.S().Warn("JSON-RPC: could not retrieve block: ", err.Error())
+		return nil, &rpcError{Code: codeSystemError, Message: "could not retrieve block"}
+	}
+
+	transactions, err := crud.GetBlockTransactionModel().SelectMany(ctx, block.Number)
+	if errors.Is(err, crud.ErrQueryTimeout) {
+		return nil, &rpcError{Code: codeSystemError, Message: crud.ErrQueryTimeout.Error()}
+	} else if err != nil {
+		zap.S().Warn("JSON-RPC: could not retrieve block transactions: ", err.Error())
+		return nil, &rpcError{Code: codeSystemError, Message: "could not retrieve block transactions"}
+	}
+
+	return transformBlockToICONBlock(block, transactions), nil
+}
+
+func transformBlockToICONBlock(block *models.Block, transactions *[]models.BlockTransaction) *iconBlock {
+	confirmedTransactions := make([]*iconTransaction, len(*transactions))
+	for i := range *transactions {
+		confirmedTransactions[i] = &iconTransaction{
+			TxHash: (*transactions)[i].TransactionHash,
+			Value:  (*transactions)[i].Amount,
+		}
+	}
+
+	return &iconBlock{
+		Version:                  block.Version,
+		PrevBlockHash:            block.ParentHash,
+		MerkleTreeRootHash:       block.MerkleRootHash,
+		TimeStamp:                block.Timestamp,
+		ConfirmedTransactionList: confirmedTransactions,
+		BlockHash:                block.Hash,
+		Height:                   block.Number,
+		PeerID:                   block.PeerId,
+		Signature:                block.Signature,
+		NextLeader:               block.NextLeader,
+	}
+}
+
+// parseHexUint32 - parse an ICON hex string, e.g. "0x1a"
+func parseHexUint32(value string) (uint32, error) {
+	if !strings.HasPrefix(value, "0x") {
+		return 0, errors.New("missing 0x prefix")
+	}
+
+	number, err := strconv.ParseUint(value[2:], 16, 32)
+	return uint32(number), err
+}
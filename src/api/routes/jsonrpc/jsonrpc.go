@@ -0,0 +1,147 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"github.com/geometry-labs/icon-blocks/config"
+)
+
+// JSON-RPC 2.0 error codes
+// NOTE codes below -31000 are the ones returned by ICON nodes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeSystemError    = -31000
+	codeNotFound       = -31004
+)
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	ID      json.RawMessage `json:"id"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// methodHandler - resolve the params of a method call into a result
+type methodHandler func(ctx context.Context, params json.RawMessage) (interface{}, *rpcError)
+
+// methods - supported ICON JSON-RPC v3 methods
+var methods = map[string]methodHandler{
+	"icx_getLastBlock":     icxGetLastBlock,
+	"icx_getBlockByHeight": icxGetBlockByHeight,
+	"icx_getBlockByHash":   icxGetBlockByHash,
+}
+
+// AddHandlers - add ICON JSON-RPC v3 endpoint to fiber router
+func AddHandlers(app *fiber.App) {
+	app.Post(config.Config.JSONRPCPath, handlerJSONRPC)
+}
+
+// JSON-RPC
+// @Summary ICON JSON-RPC v3
+// @Description icx_getLastBlock, icx_getBlockByHeight and icx_getBlockByHash answered from the blocks table
+// @Tags JSON-RPC
+// @Accept json
+// @Produce json
+// @Router /api/v3 [post]
+// @Success 200 {object} map[string]interface{}
+func handlerJSONRPC(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	body, _ := json.Marshal(handleBody(c.UserContext(), c.Body()))
+	return c.SendString(string(body))
+}
+
+// handleBody - handle a single request or a batch
+// NOTE batches above JSONRPCMaxBatchSize are rejected with a single error
+func handleBody(ctx context.Context, body []byte) interface{} {
+	body = bytes.TrimSpace(body)
+
+	// Batch
+	if len(body) > 0 && body[0] == '[' {
+		requests := []json.RawMessage{}
+		err := json.Unmarshal(body, &requests)
+		if err != nil {
+			return errorResponse(nil, codeParseError, "parse error")
+		}
+		if len(requests) == 0 {
+			return errorResponse(nil, codeInvalidRequest, "empty batch")
+		}
+		if len(requests) > config.Config.JSONRPCMaxBatchSize {
+			return errorResponse(nil, codeInvalidRequest, fmt.Sprintf("batch too large, max %d requests", config.Config.JSONRPCMaxBatchSize))
+		}
+
+		responses := make([]*rpcResponse, len(requests))
+		for i, request := range requests {
+			responses[i] = handleRequest(ctx, request)
+		}
+		return responses
+	}
+
+	return handleRequest(ctx, body)
+}
+
+func handleRequest(ctx context.Context, body []byte) *rpcResponse {
+	request := &rpcRequest{}
+	err := json.Unmarshal(body, request)
+	if err != nil {
+		return errorResponse(nil, codeParseError, "parse error")
+	}
+	if request.JSONRPC != "2.0" || request.Method == "" {
+		return errorResponse(request.ID, codeInvalidRequest, "invalid request")
+	}
+
+	method, ok := methods[request.Method]
+	if !ok {
+		return errorResponse(request.ID, codeMethodNotFound, "method not found")
+	}
+
+	result, rpcErr := method(ctx, request.Params)
+	if rpcErr != nil {
+		return &rpcResponse{
+			JSONRPC: "2.0",
+			Error:   rpcErr,
+			ID:      request.ID,
+		}
+	}
+
+	return &rpcResponse{
+		JSONRPC: "2.0",
+		Result:  result,
+		ID:      request.ID,
+	}
+}
+
+func errorResponse(id json.RawMessage, code int, message string) *rpcResponse {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+
+	return &rpcResponse{
+		JSONRPC: "2.0",
+		Error: &rpcError{
+			Code:    code,
+			Message: message,
+		},
+		ID: id,
+	}
+}
@@ -0,0 +1,89 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/models"
+)
+
+func TestHandleBodyErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.JSONRPCMaxBatchSize = 2
+
+	// Parse error
+	response := handleBody(context.Background(), []byte(`{"jsonrpc": "2.0",`)).(*rpcResponse)
+	assert.Equal(codeParseError, response.Error.Code)
+	assert.Equal(json.RawMessage("null"), response.ID)
+
+	// Invalid request
+	response = handleBody(context.Background(), []byte(`{"jsonrpc": "1.0", "method": "icx_getLastBlock", "id": 1}`)).(*rpcResponse)
+	assert.Equal(codeInvalidRequest, response.Error.Code)
+	assert.Equal(json.RawMessage("1"), response.ID)
+
+	// Method not found
+	response = handleBody(context.Background(), []byte(`{"jsonrpc": "2.0", "method": "icx_sendTransaction", "id": 2}`)).(*rpcResponse)
+	assert.Equal(codeMethodNotFound, response.Error.Code)
+
+	// Invalid params
+	response = handleBody(context.Background(), []byte(`{"jsonrpc": "2.0", "method": "icx_getBlockByHeight", "params": {"height": "12"}, "id": 3}`)).(*rpcResponse)
+	assert.Equal(codeInvalidParams, response.Error.Code)
+	response = handleBody(context.Background(), []byte(`{"jsonrpc": "2.0", "method": "icx_getBlockByHash", "params": {}, "id": 4}`)).(*rpcResponse)
+	assert.Equal(codeInvalidParams, response.Error.Code)
+
+	// Batch
+	responses := handleBody(context.Background(), []byte(`[{"jsonrpc": "2.0", "method": "a", "id": 5}, {"jsonrpc": "2.0", "method": "b", "id": 6}]`)).([]*rpcResponse)
+	assert.Equal(2, len(responses))
+	assert.Equal(json.RawMessage("6"), responses[1].ID)
+
+	// Batch too large
+	response = handleBody(context.Background(), []byte(`[{"jsonrpc": "2.0", "method": "a", "id": 7}, {"jsonrpc": "2.0", "method": "b", "id": 8}, {"jsonrpc": "2.0", "method": "c", "id": 9}]`)).(*rpcResponse)
+	assert.Equal(codeInvalidRequest, response.Error.Code)
+	assert.Equal(json.RawMessage("null"), response.ID)
+}
+
+func TestParseHexUint32(t *testing.T) {
+	assert := assert.New(t)
+
+	number, err := parseHexUint32("0x1a")
+	assert.Equal(nil, err)
+	assert.Equal(uint32(26), number)
+
+	_, err = parseHexUint32("26")
+	assert.NotEqual(nil, err)
+
+	_, err = parseHexUint32("0xzz")
+	assert.NotEqual(nil, err)
+}
+
+func TestTransformBlockToICONBlock(t *testing.T) {
+	assert := assert.New(t)
+
+	block := &models.Block{
+		Number:         33788433,
+		Hash:           "1a71219583a6e91f5bead3530c5071dace2b52689ee21123e31297eee1946668",
+		ParentHash:     "47ae10336a3a7740188e5dd4d1f548d7f10a7e1e18ed250b9d9bab7df57de2a8",
+		MerkleRootHash: "db42f34fc27f22e3e0cea597a1dc0016160b0ecb775065efa60de1132ebf31d9",
+		Timestamp:      1619815590946702,
+		Version:        "0.5",
+		PeerId:         "hx262afdeda4eba10fe41fa5ef21796ac2bdcc6629",
+	}
+	transactions := &[]models.BlockTransaction{}
+	*transactions = append(*transactions, models.BlockTransaction{TransactionHash: "0xabc", Amount: "0x1"})
+
+	result, _ := json.Marshal(transformBlockToICONBlock(block, transactions))
+
+	expected := map[string]interface{}{}
+	json.Unmarshal(result, &expected)
+	assert.Equal(float64(33788433), expected["height"])
+	assert.Equal(block.Hash, expected["block_hash"])
+	assert.Equal(block.ParentHash, expected["prev_block_hash"])
+	assert.Equal(block.MerkleRootHash, expected["merkle_tree_root_hash"])
+	assert.Equal(float64(1619815590946702), expected["time_stamp"])
+	assert.Equal("0xabc", expected["confirmed_transaction_list"].([]interface{})[0].(map[string]interface{})["txHash"])
+}
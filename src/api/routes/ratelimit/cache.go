@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/geometry-labs/icon-blocks/models"
+)
+
+// keyCache - API keys by hash with an expiry
+type keyCache struct {
+	mutex     sync.RWMutex
+	entries   map[string]keyCacheEntry
+	lastSweep time.Time
+}
+
+type keyCacheEntry struct {
+	apiKey  *models.ApiKey
+	expires time.Time
+}
+
+var apiKeyCache = newKeyCache()
+
+func newKeyCache() *keyCache {
+	return &keyCache{
+		entries: map[string]keyCacheEntry{},
+	}
+}
+
+// get - cached API key, ok is false when missing or expired
+func (k *keyCache) get(keyHash string) (*models.ApiKey, bool) {
+	k.mutex.RLock()
+	entry, ok := k.entries[keyHash]
+	k.mutex.RUnlock()
+
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+
+	return entry.apiKey, true
+}
+
+// set - cache an API key, or a miss when apiKey is nil
+// NOTE expired entries are dropped at most once per ttl so the cache does not grow with one-off keys
+func (k *keyCache) set(keyHash string, apiKey *models.ApiKey, ttl time.Duration) {
+	now := time.Now()
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	if now.Sub(k.lastSweep) > ttl {
+		for hash, entry := range k.entries {
+			if now.After(entry.expires) {
+				delete(k.entries, hash)
+			}
+		}
+		k.lastSweep = now
+	}
+
+	k.entries[keyHash] = keyCacheEntry{
+		apiKey:  apiKey,
+		expires: now.Add(ttl),
+	}
+}
@@ -0,0 +1,157 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-blocks/api/routes/apierror"
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/logging"
+	"github.com/geometry-labs/icon-blocks/metrics"
+	"github.com/geometry-labs/icon-blocks/models"
+	"github.com/geometry-labs/icon-blocks/redis"
+)
+
+// tierIP - metrics label for clients without an API key
+const tierIP = "ip"
+
+// limit - bucket and requests per minute for a client
+type limit struct {
+	key       string
+	tier      string
+	perMinute int
+}
+
+// Swapped out in tests
+var (
+	selectAPIKey = func(ctx context.Context, keyHash string) (*models.ApiKey, error) {
+		return crud.GetApiKeyModel().SelectOne(ctx, keyHash)
+	}
+	takeToken = func(ctx context.Context, key string, capacity int, perSecond float64) (*redis.TokenBucketResult, error) {
+		return redis.GetRedisClient().TakeToken(ctx, key, capacity, perSecond)
+	}
+)
+
+// Handler - rate limit requests per API key, or per client IP without one
+// NOTE buckets live in redis so limits hold across API replicas
+func Handler(c *fiber.Ctx) error {
+	if !config.Config.RateLimitEnabled {
+		return c.Next()
+	}
+
+	l, err := resolveLimit(c)
+	if err != nil {
+		return err
+	}
+	if l.perMinute <= 0 {
+		// Unlimited
+		return c.Next()
+	}
+
+	result, err := takeToken(c.UserContext(), l.key, l.perMinute, float64(l.perMinute)/60)
+	if err != nil {
+		// NOTE fail open, the API stays up when redis is not
+		logging.FromContext(c.UserContext()).Warn("Rate limit: unable to take token: ", err.Error())
+		return c.Next()
+	}
+
+	c.Set("X-RateLimit-Limit", strconv.Itoa(l.perMinute))
+	c.Set("X-RateLimit-Remaining", strconv.FormatInt(result.Remaining, 10))
+	c.Set("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+	if !result.Allowed {
+		metrics.RateLimitRejectedCounter.WithLabelValues(l.tier).Inc()
+
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+		return apierror.New(fiber.StatusTooManyRequests, apierror.CodeRateLimited, "rate limit exceeded")
+	}
+
+	return c.Next()
+}
+
+// resolveLimit - limit for the API key of the request, or for the client IP
+func resolveLimit(c *fiber.Ctx) (*limit, error) {
+	rawKey := c.Get(config.Config.RateLimitAPIKeyHeader)
+	if rawKey == "" {
+		rawKey = c.Query("api_key")
+	}
+
+	if rawKey == "" {
+		return &limit{
+			key:       "ratelimit:ip:" + clientIP(c),
+			tier:      tierIP,
+			perMinute: config.Config.RateLimitIPPerMinute,
+		}, nil
+	}
+
+	keyHash := HashAPIKey(rawKey)
+	apiKey, err := getAPIKey(c.UserContext(), keyHash)
+	if err != nil {
+		return nil, apierror.Internal("could not check api key", err)
+	}
+	if apiKey == nil || !apiKey.IsActive {
+		return nil, apierror.New(fiber.StatusUnauthorized, apierror.CodeInvalidAPIKey, "invalid api key")
+	}
+
+	perMinute, ok := config.Config.RateLimitTiers[apiKey.Tier]
+	if !ok {
+		logging.FromContext(c.UserContext()).Warn("Rate limit: unknown tier '", apiKey.Tier, "', using the IP limit")
+		perMinute = config.Config.RateLimitIPPerMinute
+	}
+
+	return &limit{
+		key:       "ratelimit:key:" + keyHash,
+		tier:      apiKey.Tier,
+		perMinute: perMinute,
+	}, nil
+}
+
+// clientIP - client IP, from the proxy header when sent by a trusted proxy
+// NOTE the last X-Forwarded-For address is the one added by the trusted proxy, earlier ones are set by the client
+func clientIP(c *fiber.Ctx) string {
+	ip := c.IP()
+	if i := strings.LastIndex(ip, ","); i >= 0 {
+		ip = ip[i+1:]
+	}
+
+	return strings.TrimSpace(ip)
+}
+
+// HashAPIKey - hex sha256 digest stored in the api_keys table
+func HashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// getAPIKey - API key by hash, nil if there is none
+// NOTE lookups are cached, including misses, so keys are not checked against postgres per request
+func getAPIKey(ctx context.Context, keyHash string) (*models.ApiKey, error) {
+	if apiKey, ok := apiKeyCache.get(keyHash); ok {
+		return apiKey, nil
+	}
+
+	apiKey, err := selectAPIKey(ctx, keyHash)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		apiKey = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	apiKeyCache.set(keyHash, apiKey, time.Duration(config.Config.RateLimitAPIKeyCacheTime)*time.Second)
+
+	return apiKey, nil
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
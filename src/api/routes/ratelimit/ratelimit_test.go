@@ -0,0 +1,150 @@
+package ratelimit
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-blocks/api/routes/apierror"
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/models"
+	"github.com/geometry-labs/icon-blocks/redis"
+)
+
+func TestHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.RateLimitEnabled = true
+	config.Config.RateLimitIPPerMinute = 2
+	config.Config.RateLimitTiers = map[string]int{"standard": 600, "unlimited": 0}
+	config.Config.RateLimitAPIKeyHeader = "X-API-Key"
+	config.Config.RateLimitAPIKeyCacheTime = 60
+
+	apiKeys := map[string]*models.ApiKey{
+		HashAPIKey("standard-key"):  {Tier: "standard", IsActive: true},
+		HashAPIKey("unlimited-key"): {Tier: "unlimited", IsActive: true},
+		HashAPIKey("revoked-key"):   {Tier: "standard", IsActive: false},
+	}
+	selectAPIKey = func(ctx context.Context, keyHash string) (*models.ApiKey, error) {
+		apiKey, ok := apiKeys[keyHash]
+		if !ok {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return apiKey, nil
+	}
+
+	// In memory buckets, without refill
+	buckets := map[string]int{}
+	takeToken = func(ctx context.Context, key string, capacity int, perSecond float64) (*redis.TokenBucketResult, error) {
+		tokens, ok := buckets[key]
+		if !ok {
+			tokens = capacity
+		}
+		if tokens == 0 {
+			return &redis.TokenBucketResult{RetryAfter: 1500 * time.Millisecond, Reset: time.Minute}, nil
+		}
+		buckets[key] = tokens - 1
+		return &redis.TokenBucketResult{Allowed: true, Remaining: int64(tokens - 1)}, nil
+	}
+
+	app := fiber.New(fiber.Config{ErrorHandler: apierror.Handler})
+	app.Use(Handler)
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	get := func(apiKey string) *http.Response {
+		req := httptest.NewRequest("GET", "/", nil)
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+		resp, err := app.Test(req)
+		assert.Equal(nil, err)
+		return resp
+	}
+
+	// Per IP
+	resp := get("")
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+	assert.Equal("2", resp.Header.Get("X-RateLimit-Limit"))
+	assert.Equal("1", resp.Header.Get("X-RateLimit-Remaining"))
+	get("")
+	resp = get("")
+	assert.Equal(fiber.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal("2", resp.Header.Get(fiber.HeaderRetryAfter))
+	assert.Equal("60", resp.Header.Get("X-RateLimit-Reset"))
+
+	// Per API key, with its own bucket
+	resp = get("standard-key")
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+	assert.Equal("600", resp.Header.Get("X-RateLimit-Limit"))
+
+	// Unlimited tier
+	resp = get("unlimited-key")
+	assert.Equal(fiber.StatusOK, resp.StatusCode)
+	assert.Equal("", resp.Header.Get("X-RateLimit-Limit"))
+
+	// Unknown and revoked keys
+	assert.Equal(fiber.StatusUnauthorized, get("unknown-key").StatusCode)
+	assert.Equal(fiber.StatusUnauthorized, get("revoked-key").StatusCode)
+}
+
+func TestKeyCache(t *testing.T) {
+	assert := assert.New(t)
+
+	cache := newKeyCache()
+
+	_, ok := cache.get("a")
+	assert.Equal(false, ok)
+
+	// Misses are cached
+	cache.set("a", nil, time.Minute)
+	apiKey, ok := cache.get("a")
+	assert.Equal(true, ok)
+	assert.Nil(apiKey)
+
+	// Expired
+	cache.set("b", &models.ApiKey{Tier: "standard"}, -time.Second)
+	_, ok = cache.get("b")
+	assert.Equal(false, ok)
+}
+
+func TestClientIP(t *testing.T) {
+	assert := assert.New(t)
+
+	newApp := func(trustedProxies []string) *fiber.App {
+		app := fiber.New(fiber.Config{
+			ProxyHeader:             "X-Forwarded-For",
+			EnableTrustedProxyCheck: true,
+			TrustedProxies:          trustedProxies,
+		})
+		app.Get("/", func(c *fiber.Ctx) error {
+			return c.SendString(clientIP(c))
+		})
+		return app
+	}
+
+	get := func(app *fiber.App, forwardedFor string) string {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		resp, err := app.Test(req)
+		assert.Equal(nil, err)
+		body, _ := ioutil.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	// Untrusted, header is ignored
+	assert.Equal("0.0.0.0", get(newApp(nil), "203.0.113.7"))
+
+	// Trusted, address added by the proxy
+	trusted := newApp([]string{"0.0.0.0"})
+	assert.Equal("203.0.113.7", get(trusted, "203.0.113.7"))
+	assert.Equal("203.0.113.7", get(trusted, "198.51.100.1, 203.0.113.7"))
+}
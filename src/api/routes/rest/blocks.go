@@ -2,11 +2,15 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"strconv"
 
 	fiber "github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 
+	"github.com/geometry-labs/icon-blocks/api/routes/apierror"
 	"github.com/geometry-labs/icon-blocks/config"
 	"github.com/geometry-labs/icon-blocks/crud"
 )
@@ -32,9 +36,54 @@ type paramsGetBlocks struct {
 	Sort        string `query:"sort"`
 }
 
+// setDefaults - fill in unset parameters
+func (p *paramsGetBlocks) setDefaults() {
+	if p.Limit == 0 {
+		p.Limit = 25
+	}
+	if p.Sort == "" {
+		p.Sort = "desc"
+	}
+}
+
+// validate - check every parameter, returns all invalid fields
+func (p *paramsGetBlocks) validate() []apierror.FieldError {
+	details := []apierror.FieldError{}
+
+	if p.Limit < 1 || p.Limit > config.Config.MaxPageSize {
+		details = append(details, apierror.FieldError{
+			Field:   "limit",
+			Message: fmt.Sprintf("must be between 1 and %d", config.Config.MaxPageSize),
+		})
+	}
+	if p.Skip < 0 || p.Skip > config.Config.MaxPageSkip {
+		details = append(details, apierror.FieldError{
+			Field:   "skip",
+			Message: fmt.Sprintf("must be between 0 and %d", config.Config.MaxPageSkip),
+		})
+	}
+	if p.EndNumber != 0 && p.EndNumber < p.StartNumber {
+		details = append(details, apierror.FieldError{
+			Field:   "end_number",
+			Message: "must not be less than start_number",
+		})
+	}
+	if p.Sort != "desc" && p.Sort != "asc" {
+		details = append(details, apierror.FieldError{
+			Field:   "sort",
+			Message: "must be asc or desc",
+		})
+	}
+
+	return details
+}
+
 // Blocks
 // @Summary Get Blocks
 // @Description get historical blocks
+// @Description
+// @Description 200 with an empty list when no block matches
+// @Description 422 lists every invalid query parameter in error.details
 // @Tags Blocks
 // @BasePath /api/v1
 // @Accept */*
@@ -49,42 +98,26 @@ type paramsGetBlocks struct {
 // @Param sort query string false "desc or asc"
 // @Router /api/v1/blocks [get]
 // @Success 200 {object} []models.BlockAPIList
-// @Failure 422 {object} map[string]interface{}
+// @Header 200 {integer} X-TOTAL-COUNT "total number of blocks"
+// @Failure 422 {object} apierror.Response
+// @Failure 500 {object} apierror.Response
+// @Failure 503 {object} apierror.Response
 func handlerGetBlocks(c *fiber.Ctx) error {
 	params := &paramsGetBlocks{}
 	if err := c.QueryParser(params); err != nil {
 		zap.S().Warnf("Blocks Get Handler ERROR: %s", err.Error())
 
-		c.Status(422)
-		return c.SendString(`{"error": "could not parse query parameters"}`)
-	}
-
-	// Default params
-	if params.Limit == 0 {
-		params.Limit = 25
-	}
-	if params.Sort == "" {
-		params.Sort = "desc"
+		return apierror.New(fiber.StatusUnprocessableEntity, apierror.CodeInvalidParameters, "could not parse query parameters")
 	}
 
 	// Check params
-	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid limit"}`)
-	}
-	if params.Skip < 0 || params.Skip > config.Config.MaxPageSkip {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid skip"}`)
-	}
-	if params.EndNumber < params.StartNumber {
-		c.Status(422)
-		return c.SendString(`{"error": "end_number is less than start_number"}`)
-	}
-	if params.Sort != "desc" && params.Sort != "asc" {
-		params.Sort = "desc"
+	params.setDefaults()
+	if details := params.validate(); len(details) > 0 {
+		return apierror.InvalidParameters(details)
 	}
 
 	blocks, err := crud.GetBlockModel().SelectMany(
+		c.UserContext(),
 		params.Limit,
 		params.Skip,
 		params.Number,
@@ -95,16 +128,11 @@ func handlerGetBlocks(c *fiber.Ctx) error {
 		params.Sort,
 	)
 	if err != nil {
-		c.Status(500)
-		return c.SendString(`{"error": "could retrieve blocks"}`)
-	}
-	if len(*blocks) == 0 {
-		// No Content
-		c.Status(204)
+		return queryError("could not retrieve blocks", err)
 	}
 
 	// Set X-TOTAL-COUNT
-	counter, err := crud.GetBlockCountModel().SelectCount("block")
+	counter, err := crud.GetBlockCountModel().SelectCount(c.UserContext(), "block")
 	if err != nil {
 		counter = 0
 		zap.S().Warn("Could not retrieve block count: ", err.Error())
@@ -126,28 +154,40 @@ func handlerGetBlocks(c *fiber.Ctx) error {
 // @Param number path int true "block number"
 // @Router /api/v1/blocks/{number} [get]
 // @Success 200 {object} models.Block
-// @Failure 422 {object} map[string]interface{}
+// @Failure 404 {object} apierror.Response
+// @Failure 422 {object} apierror.Response
+// @Failure 500 {object} apierror.Response
+// @Failure 503 {object} apierror.Response
 func handlerGetBlockDetails(c *fiber.Ctx) error {
 	numberRaw := c.Params("number")
 
-	if numberRaw == "" {
-		c.Status(422)
-		return c.SendString(`{"error": "number required"}`)
-	}
-
 	// Is number?
 	number, err := strconv.ParseUint(numberRaw, 10, 32)
-	if err != nil {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid number"}`)
+	if err != nil || number == 0 {
+		return apierror.InvalidParameters([]apierror.FieldError{{
+			Field:   "number",
+			Message: "must be a positive block number",
+		}})
 	}
 
-	block, err := crud.GetBlockModel().SelectOne(uint32(number))
-	if err != nil {
-		c.Status(404)
-		return c.SendString(`{"error": "no block found"}`)
+	block, err := crud.GetBlockModel().SelectOne(c.UserContext(), uint32(number))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apierror.NotFound("no block found")
+	} else if err != nil {
+		return queryError("could not retrieve block", err)
 	}
 
 	body, _ := json.Marshal(&block)
 	return c.SendString(string(body))
 }
+
+// queryError - error envelope for a failed crud read
+func queryError(message string, err error) error {
+	if errors.Is(err, crud.ErrQueryRejected) {
+		return apierror.New(fiber.StatusUnprocessableEntity, apierror.CodeQueryTooExpensive, crud.ErrQueryRejected.Error())
+	} else if errors.Is(err, crud.ErrQueryTimeout) {
+		return apierror.New(fiber.StatusServiceUnavailable, apierror.CodeQueryTimeout, crud.ErrQueryTimeout.Error())
+	}
+
+	return apierror.Internal(message, err)
+}
@@ -0,0 +1,60 @@
+package rest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-blocks/api/routes/apierror"
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/crud"
+)
+
+func TestParamsGetBlocksValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.MaxPageSize = 100
+	config.Config.MaxPageSkip = 1000
+
+	// Defaults are valid
+	params := &paramsGetBlocks{}
+	params.setDefaults()
+	assert.Equal(0, len(params.validate()))
+
+	// Start number only
+	params = &paramsGetBlocks{StartNumber: 10}
+	params.setDefaults()
+	assert.Equal(0, len(params.validate()))
+
+	// Every invalid field is reported
+	params = &paramsGetBlocks{
+		Limit:       1000,
+		Skip:        -1,
+		StartNumber: 10,
+		EndNumber:   5,
+		Sort:        "up",
+	}
+	params.setDefaults()
+
+	fields := []string{}
+	for _, detail := range params.validate() {
+		fields = append(fields, detail.Field)
+	}
+	assert.Equal([]string{"limit", "skip", "end_number", "sort"}, fields)
+}
+
+func TestQueryError(t *testing.T) {
+	assert := assert.New(t)
+
+	// Cost guard
+	err := queryError("could not retrieve blocks", fmt.Errorf("select: %w", crud.ErrQueryRejected)).(*apierror.Error)
+	assert.Equal(422, err.Status)
+	assert.Equal(apierror.CodeQueryTooExpensive, err.Code)
+	assert.Equal(crud.ErrQueryRejected.Error(), err.Message)
+
+	// Statement timeout
+	err = queryError("could not retrieve blocks", crud.ErrQueryTimeout).(*apierror.Error)
+	assert.Equal(503, err.Status)
+	assert.Equal(apierror.CodeQueryTimeout, err.Code)
+}
@@ -0,0 +1,233 @@
+package sse
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-blocks/api/routes/apierror"
+	"github.com/geometry-labs/icon-blocks/api/routes/stream"
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/global"
+	"github.com/geometry-labs/icon-blocks/logging"
+	"github.com/geometry-labs/icon-blocks/models"
+	"github.com/geometry-labs/icon-blocks/redis"
+)
+
+// NOTE blocks are replayed in pages of this size
+const replayPageSize = 100
+
+// BlocksAddHandlers - add server-sent event endpoints to fiber router
+// NOTE must be added before rest.BlocksAddHandlers so /stream is not parsed as a block number
+func BlocksAddHandlers(app *fiber.App) {
+
+	prefix := config.Config.RestPrefix + "/blocks"
+
+	app.Get(prefix+"/stream", handlerStreamBlocks)
+}
+
+// Blocks Stream
+// @Summary Stream Blocks
+// @Description stream new blocks as server-sent events, event IDs are block numbers
+// @Description a reset event with the last replayed block number is sent when a resume is too far behind
+// @Tags Blocks
+// @BasePath /api/v1
+// @Accept */*
+// @Produce text/event-stream
+// @Param Last-Event-ID header int false "resume after this block number"
+// @Param last_event_id query int false "resume after this block number, for clients that cannot set headers"
+// @Param min_transaction_count query int false "only send blocks with at least this many transactions"
+// @Router /api/v1/blocks/stream [get]
+// @Success 200 {object} models.BlockWebsocket
+// @Failure 422 {object} apierror.Response
+func handlerStreamBlocks(c *fiber.Ctx) error {
+
+	// Filters
+	filter, err := stream.ParseBlockFilter(c.Query)
+	if err != nil {
+		return apierror.InvalidParameters([]apierror.FieldError{{
+			Field:   "min_transaction_count",
+			Message: "must be a positive integer",
+		}})
+	}
+
+	// Resume
+	lastEventIDRaw := c.Get("Last-Event-ID")
+	if lastEventIDRaw == "" {
+		lastEventIDRaw = c.Query("last_event_id")
+	}
+	lastNumber := uint64(0)
+	if lastEventIDRaw != "" {
+		lastNumber, err = strconv.ParseUint(lastEventIDRaw, 10, 32)
+		if err != nil {
+			return apierror.InvalidParameters([]apierror.FieldError{{
+				Field:   "Last-Event-ID",
+				Message: "must be a block number",
+			}})
+		}
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// NOTE the stream outlives the handler and its request context
+	ctx := logging.WithRequestID(global.ShutdownContext(), logging.RequestIDFromContext(c.UserContext()))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		streamBlocks(ctx, w, filter, uint32(lastNumber))
+	})
+
+	return nil
+}
+
+func streamBlocks(ctx context.Context, w *bufio.Writer, filter *stream.BlockFilter, lastNumber uint32) {
+	stream.AddConnection()
+	defer stream.ConnectionDone()
+
+	// Add broadcaster
+	// NOTE subscribe before replaying so no block is missed in between
+	msgChan := redis.GetBroadcaster().NewBroadcastChannel()
+	broadcasterID := redis.GetBroadcaster().AddBroadcastChannel(msgChan)
+	defer func() {
+		// Remove broadcaster
+		redis.GetBroadcaster().RemoveBroadcastChannel(broadcasterID)
+	}()
+
+	// Tell EventSource how long to wait before reconnecting
+	fmt.Fprintf(w, "retry: %d\n\n", config.Config.SSEKeepaliveInterval*1000)
+	if err := w.Flush(); err != nil {
+		return
+	}
+
+	// Replay
+	if lastNumber != 0 {
+		var err error
+		lastNumber, err = replayBlocks(ctx, w, filter, lastNumber)
+		if err != nil {
+			return
+		}
+	}
+
+	keepaliveTicker := time.NewTicker(time.Duration(config.Config.SSEKeepaliveInterval) * time.Second)
+	defer keepaliveTicker.Stop()
+
+	for {
+		select {
+		case msg, ok := <-msgChan:
+			if !ok {
+				// Removed by broadcaster
+				// NOTE client reconnects with Last-Event-ID
+				return
+			}
+
+			block := &models.BlockWebsocket{}
+			err := json.Unmarshal(msg, block)
+			if err != nil {
+				zap.S().Warn("SSE: unable to parse broadcast message: ", err.Error())
+				continue
+			}
+
+			// Already sent during replay
+			if block.Number <= lastNumber || !filter.Match(block) {
+				continue
+			}
+
+			if err := writeBlockEvent(w, block); err != nil {
+				return
+			}
+			lastNumber = block.Number
+		case <-keepaliveTicker.C:
+			// Comment line, ignored by EventSource
+			fmt.Fprint(w, ": keepalive\n\n")
+			if err := w.Flush(); err != nil {
+				return
+			}
+		case <-stream.ShutdownChan():
+			return
+		}
+	}
+}
+
+// replayBlocks - send blocks after lastNumber, oldest first, until the live head
+// Returns: last replayed block number, write error (if present)
+// NOTE after SSEReplayLimit blocks a reset event is sent and the stream continues live
+func replayBlocks(ctx context.Context, w *bufio.Writer, filter *stream.BlockFilter, lastNumber uint32) (uint32, error) {
+	replayed := 0
+
+	for {
+		pageSize := replayPageSize
+		if remaining := config.Config.SSEReplayLimit - replayed; remaining < pageSize {
+			pageSize = remaining
+		}
+		if pageSize <= 0 {
+			// Too far behind, client must catch up through the REST API
+			return lastNumber, writeResetEvent(w, lastNumber)
+		}
+
+		blocks, err := crud.GetBlockModel().SelectMany(
+			ctx,
+			pageSize,
+			0,
+			0,
+			lastNumber,
+			0,
+			"",
+			"",
+			"asc",
+		)
+		if err != nil {
+			zap.S().Warn("SSE: unable to replay blocks after ", lastNumber, ": ", err.Error())
+			return lastNumber, writeResetEvent(w, lastNumber)
+		}
+
+		for i := range *blocks {
+			block := &(*blocks)[i]
+
+			replay := &models.BlockWebsocket{
+				Number:           block.Number,
+				Hash:             block.Hash,
+				TransactionCount: block.TransactionCount,
+				Timestamp:        block.Timestamp,
+			}
+
+			if filter.Match(replay) {
+				if err := writeBlockEvent(w, replay); err != nil {
+					return lastNumber, err
+				}
+			}
+			lastNumber = block.Number
+		}
+		replayed += len(*blocks)
+
+		// Live head reached
+		if len(*blocks) < pageSize {
+			return lastNumber, nil
+		}
+	}
+}
+
+// writeResetEvent - tell the client blocks after lastNumber were not replayed
+func writeResetEvent(w *bufio.Writer, lastNumber uint32) error {
+	fmt.Fprintf(w, "event: reset\ndata: {\"last_number\":%d}\n\n", lastNumber)
+
+	return w.Flush()
+}
+
+func writeBlockEvent(w *bufio.Writer, block *models.BlockWebsocket) error {
+	data, err := json.Marshal(block)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "id: %d\nevent: block\ndata: %s\n\n", block.Number, data)
+
+	return w.Flush()
+}
@@ -0,0 +1,60 @@
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/geometry-labs/icon-blocks/models"
+)
+
+// BlockFilter - filters applied to live block messages
+type BlockFilter struct {
+	MinTransactionCount uint32
+}
+
+// ParseBlockFilter - read filter options from query parameters
+// query is the Query method of a fiber or websocket context
+func ParseBlockFilter(query func(key string, defaultValue ...string) string) (*BlockFilter, error) {
+	filter := &BlockFilter{}
+
+	minTransactionCountRaw := query("min_transaction_count")
+	if minTransactionCountRaw != "" {
+		minTransactionCount, err := strconv.ParseUint(minTransactionCountRaw, 10, 32)
+		if err != nil {
+			return nil, errors.New("invalid min_transaction_count")
+		}
+		filter.MinTransactionCount = uint32(minTransactionCount)
+	}
+
+	return filter, nil
+}
+
+// IsEmpty - true if the filter lets every message through
+func (f *BlockFilter) IsEmpty() bool {
+	return f.MinTransactionCount == 0
+}
+
+// Match - check a block against the filter
+func (f *BlockFilter) Match(block *models.BlockWebsocket) bool {
+	if block.TransactionCount < f.MinTransactionCount {
+		return false
+	}
+
+	return true
+}
+
+// MatchJSON - check a JSON encoded BlockWebsocket against the filter
+func (f *BlockFilter) MatchJSON(msg []byte) bool {
+	if f.IsEmpty() {
+		return true
+	}
+
+	block := &models.BlockWebsocket{}
+	err := json.Unmarshal(msg, block)
+	if err != nil {
+		return false
+	}
+
+	return f.Match(block)
+}
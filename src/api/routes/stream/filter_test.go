@@ -0,0 +1,46 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-blocks/models"
+)
+
+func TestParseBlockFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	query := func(values map[string]string) func(string, ...string) string {
+		return func(key string, _ ...string) string {
+			return values[key]
+		}
+	}
+
+	filter, err := ParseBlockFilter(query(map[string]string{}))
+	assert.Equal(nil, err)
+	assert.Equal(true, filter.IsEmpty())
+
+	filter, err = ParseBlockFilter(query(map[string]string{"min_transaction_count": "2"}))
+	assert.Equal(nil, err)
+	assert.Equal(uint32(2), filter.MinTransactionCount)
+
+	_, err = ParseBlockFilter(query(map[string]string{"min_transaction_count": "-1"}))
+	assert.NotEqual(nil, err)
+}
+
+func TestBlockFilterMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	filter := &BlockFilter{MinTransactionCount: 2}
+
+	assert.Equal(false, filter.Match(&models.BlockWebsocket{TransactionCount: 1}))
+	assert.Equal(true, filter.Match(&models.BlockWebsocket{TransactionCount: 2}))
+
+	assert.Equal(false, filter.MatchJSON([]byte(`{"number":1,"transaction_count":1}`)))
+	assert.Equal(true, filter.MatchJSON([]byte(`{"number":1,"transaction_count":3}`)))
+	assert.Equal(false, filter.MatchJSON([]byte(`not json`)))
+
+	// Empty filter does not parse messages
+	assert.Equal(true, (&BlockFilter{}).MatchJSON([]byte(`not json`)))
+}
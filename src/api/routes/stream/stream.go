@@ -0,0 +1,48 @@
+package stream
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var shutdownChan = make(chan bool)
+var shutdownOnce sync.Once
+var connectionsWaitGroup sync.WaitGroup
+
+// ShutdownChan - closed when the API is shutting down
+func ShutdownChan() <-chan bool {
+	return shutdownChan
+}
+
+// AddConnection - track an open streaming connection
+// Call ConnectionDone when the connection closes
+func AddConnection() {
+	connectionsWaitGroup.Add(1)
+}
+
+// ConnectionDone - stop tracking a streaming connection
+func ConnectionDone() {
+	connectionsWaitGroup.Done()
+}
+
+// Shutdown - signal all streaming connections to close
+// Waits up to timeout for the connections to exit
+func Shutdown(timeout time.Duration) {
+	shutdownOnce.Do(func() {
+		close(shutdownChan)
+	})
+
+	done := make(chan bool)
+	go func() {
+		connectionsWaitGroup.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		zap.S().Warn("Streams: timed out waiting for connections to close")
+	}
+}
@@ -0,0 +1,79 @@
+package routes
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/geometry-labs/icon-blocks/tracing"
+)
+
+// fiberHeaderCarrier - propagation carrier over the request headers
+type fiberHeaderCarrier struct {
+	c *fiber.Ctx
+}
+
+// Get - request header value
+func (h fiberHeaderCarrier) Get(key string) string {
+	return h.c.Get(key)
+}
+
+// Set - response header value
+func (h fiberHeaderCarrier) Set(key string, value string) {
+	h.c.Set(key, value)
+}
+
+// Keys - request header keys
+func (h fiberHeaderCarrier) Keys() []string {
+	keys := []string{}
+	h.c.Request().Header.VisitAll(func(key []byte, _ []byte) {
+		keys = append(keys, string(key))
+	})
+
+	return keys
+}
+
+// tracingMiddleware - span around every request, continuing the caller trace if present
+// NOTE handler queries are children of this span through the user context
+func tracingMiddleware(c *fiber.Ctx) error {
+	requestID, _ := c.Locals("requestid").(string)
+
+	ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), fiberHeaderCarrier{c})
+	ctx, span := tracing.Start(
+		ctx,
+		"HTTP "+c.Method(),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("http.method", c.Method()),
+			attribute.String("http.target", c.Path()),
+			attribute.String("request_id", requestID),
+		),
+	)
+	defer span.End()
+	c.SetUserContext(ctx)
+
+	err := c.Next()
+	if err != nil {
+		// Write the error response now so its status is recorded
+		if handlerErr := c.App().Config().ErrorHandler(c, err); handlerErr != nil {
+			c.Status(fiber.StatusInternalServerError)
+		}
+		span.RecordError(err)
+		err = nil
+	}
+
+	// NOTE route is only known once the request is matched
+	status := c.Response().StatusCode()
+	span.SetName(c.Method() + " " + c.Route().Path)
+	span.SetAttributes(
+		attribute.String("http.route", c.Route().Path),
+		attribute.Int("http.status_code", status),
+	)
+	if status >= fiber.StatusInternalServerError {
+		span.SetStatus(codes.Error, "")
+	}
+
+	return err
+}
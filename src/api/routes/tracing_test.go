@@ -0,0 +1,50 @@
+package routes
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/geometry-labs/icon-blocks/api/routes/apierror"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	assert := assert.New(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	app := fiber.New(fiber.Config{ErrorHandler: apierror.Handler})
+	app.Use(requestid.New())
+	app.Use(tracingMiddleware)
+
+	var handlerSpan trace.SpanContext
+	app.Get("/blocks/:number", func(c *fiber.Ctx) error {
+		handlerSpan = trace.SpanContextFromContext(c.UserContext())
+		return apierror.NotFound("no block found")
+	})
+
+	// Caller trace is continued
+	req := httptest.NewRequest("GET", "/blocks/10", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	resp, err := app.Test(req)
+	assert.Equal(nil, err)
+	assert.Equal(fiber.StatusNotFound, resp.StatusCode)
+	assert.Equal("4bf92f3577b34da6a3ce929d0e0e4736", handlerSpan.TraceID().String())
+
+	// Span is named after the route
+	spans := recorder.Ended()
+	assert.Equal(1, len(spans))
+	assert.Equal("GET /blocks/:number", spans[0].Name())
+	assert.Equal("00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+	assert.Equal(handlerSpan.SpanID(), spans[0].SpanContext().SpanID())
+}
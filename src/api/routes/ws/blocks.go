@@ -1,11 +1,15 @@
 package ws
 
 import (
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/websocket/v2"
+	"google.golang.org/protobuf/proto"
 
+	"github.com/geometry-labs/icon-blocks/api/routes/stream"
 	"github.com/geometry-labs/icon-blocks/config"
-	"github.com/geometry-labs/icon-blocks/redis"
+	"github.com/geometry-labs/icon-blocks/models"
 )
 
 // BlocksAddHandlers - add fiber endpoint handlers for websocket connections
@@ -23,47 +27,45 @@ func BlocksAddHandlers(app *fiber.App) {
 		return fiber.ErrUpgradeRequired
 	})
 
-	app.Get(prefix+"/", websocket.New(handlerGetBlocks))
+	// Encodings
+	wsConfig := websocket.Config{
+		Subprotocols: subprotocols,
+	}
+
+	app.Get(prefix+"/", websocket.New(handlerGetBlocks, wsConfig))
+	app.Get(prefix+"/transactions", websocket.New(handlerGetBlockTransactions, wsConfig))
+	app.Get(prefix+"/internal-transactions", websocket.New(handlerGetBlockInternalTransactions, wsConfig))
+	app.Get(prefix+"/failed-transactions", websocket.New(handlerGetBlockFailedTransactions, wsConfig))
 }
 
 func handlerGetBlocks(c *websocket.Conn) {
 
-	// Add broadcaster
-	msgChan := make(chan []byte)
-	broadcasterID := redis.GetBroadcaster().AddBroadcastChannel(msgChan)
-	defer func() {
-		// Remove broadcaster
-		redis.GetBroadcaster().RemoveBroadcastChannel(broadcasterID)
-	}()
+	// Filters
+	filter, err := stream.ParseBlockFilter(c.Query)
+	if err != nil {
+		writeClose(c, websocket.ClosePolicyViolation, err.Error(), time.Duration(config.Config.WebsocketWriteTimeout)*time.Second)
+		return
+	}
 
-	// Read for close
-	clientCloseSig := make(chan bool)
-	go func() {
-		for {
-			_, _, err := c.ReadMessage()
-			if err != nil {
-				clientCloseSig <- true
-				break
-			}
-		}
-	}()
+	serveChannel(c, config.Config.RedisChannel, filter.MatchJSON, func() proto.Message {
+		return &models.BlockWebsocket{}
+	})
+}
 
-	for {
-		// Read
-		msg := <-msgChan
+func handlerGetBlockTransactions(c *websocket.Conn) {
+	serveChannel(c, config.Config.RedisChannelBlockTransactions, nil, func() proto.Message {
+		return &models.BlockTransaction{}
+	})
+}
 
-		// Broadcast
-		err := c.WriteMessage(websocket.TextMessage, msg)
-		if err != nil {
-			break
-		}
+func handlerGetBlockInternalTransactions(c *websocket.Conn) {
+	serveChannel(c, config.Config.RedisChannelBlockInternalTransactions, nil, func() proto.Message {
+		return &models.BlockInternalTransaction{}
+	})
+}
 
-		// check for client close
-		select {
-		case _ = <-clientCloseSig:
-			break
-		default:
-			continue
-		}
-	}
+func handlerGetBlockFailedTransactions(c *websocket.Conn) {
+	serveChannel(c, config.Config.RedisChannelBlockFailedTransactions, nil, func() proto.Message {
+		return &models.BlockFailedTransaction{}
+	})
 }
@@ -0,0 +1,126 @@
+package ws
+
+import (
+	"time"
+
+	"github.com/gofiber/websocket/v2"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/geometry-labs/icon-blocks/api/routes/stream"
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/metrics"
+	"github.com/geometry-labs/icon-blocks/redis"
+)
+
+// serveConnection - write messages from msgChan to the client until either side closes
+// Pings the client every WebsocketPingInterval seconds and closes the connection
+// if nothing, including a pong, is read for WebsocketIdleTimeout seconds
+// Messages are skipped when filter is set and returns false
+func serveConnection(c *websocket.Conn, msgChan chan []byte, filter func(msg []byte) bool, encoder frameEncoder) {
+	stream.AddConnection()
+	defer stream.ConnectionDone()
+
+	pingInterval := time.Duration(config.Config.WebsocketPingInterval) * time.Second
+	idleTimeout := time.Duration(config.Config.WebsocketIdleTimeout) * time.Second
+	writeTimeout := time.Duration(config.Config.WebsocketWriteTimeout) * time.Second
+
+	// Liveness
+	c.SetReadDeadline(time.Now().Add(idleTimeout))
+	c.SetPongHandler(func(string) error {
+		return c.SetReadDeadline(time.Now().Add(idleTimeout))
+	})
+
+	// Read for close
+	// NOTE inbound messages are ignored but count as activity
+	clientCloseSig := make(chan bool)
+	go func() {
+		defer close(clientCloseSig)
+
+		for {
+			_, _, err := c.ReadMessage()
+			if err != nil {
+				if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+					zap.S().Debug("Websockets: client read error: ", err.Error())
+				}
+				return
+			}
+
+			c.SetReadDeadline(time.Now().Add(idleTimeout))
+		}
+	}()
+
+	pingTicker := time.NewTicker(pingInterval)
+	defer pingTicker.Stop()
+
+	for {
+		select {
+		case msg, ok := <-msgChan:
+			if !ok {
+				// Removed by broadcaster
+				writeClose(c, websocket.CloseTryAgainLater, "slow consumer", writeTimeout)
+				return
+			}
+
+			if filter != nil && !filter(msg) {
+				continue
+			}
+
+			messageType, frame, err := encoder(msg)
+			if err != nil {
+				zap.S().Warn("Websockets: unable to encode message: ", err.Error())
+				continue
+			}
+
+			c.SetWriteDeadline(time.Now().Add(writeTimeout))
+			err = c.WriteMessage(messageType, frame)
+			if err != nil {
+				return
+			}
+		case <-pingTicker.C:
+			err := c.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout))
+			if err != nil {
+				return
+			}
+		case <-clientCloseSig:
+			return
+		case <-stream.ShutdownChan():
+			writeClose(c, websocket.CloseGoingAway, "server shutting down", writeTimeout)
+			return
+		}
+	}
+}
+
+// serveChannel - subscribe to the broadcaster of a redis channel and serve the connection
+// newMessage returns an empty proto message of the type published to the channel
+func serveChannel(c *websocket.Conn, channel string, filter func(msg []byte) bool, newMessage func() proto.Message) {
+
+	// Encoding
+	encoder := newFrameEncoder(negotiateEncoding(c), channel, newMessage)
+
+	// Add broadcaster
+	broadcaster := redis.GetChannelBroadcaster(channel)
+	msgChan := broadcaster.NewBroadcastChannel()
+	broadcasterID := broadcaster.AddBroadcastChannel(msgChan)
+	defer func() {
+		// Remove broadcaster
+		broadcaster.RemoveBroadcastChannel(broadcasterID)
+	}()
+
+	// Subscriber count
+	metrics.WebsocketSubscribersGauge.WithLabelValues(channel).Inc()
+	defer metrics.WebsocketSubscribersGauge.WithLabelValues(channel).Dec()
+
+	serveConnection(c, msgChan, filter, encoder)
+}
+
+func writeClose(c *websocket.Conn, code int, reason string, writeTimeout time.Duration) {
+	err := c.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, reason),
+		time.Now().Add(writeTimeout),
+	)
+	if err != nil {
+		zap.S().Debug("Websockets: unable to write close frame: ", err.Error())
+	}
+}
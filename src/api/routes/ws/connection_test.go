@@ -0,0 +1,131 @@
+package ws
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	wsclient "github.com/fasthttp/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-blocks/config"
+)
+
+// startConnectionServer - serve msgChan on a websocket route of a local server
+// Returns: the websocket url
+func startConnectionServer(t *testing.T, msgChan chan []byte) string {
+	config.Config.WebsocketPingInterval = 1
+	config.Config.WebsocketIdleTimeout = 2
+	config.Config.WebsocketWriteTimeout = 1
+
+	app := fiber.New()
+	app.Get("/ws", websocket.New(func(c *websocket.Conn) {
+		serveConnection(c, msgChan, nil, jsonFrameEncoder)
+	}))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go app.Listener(ln)
+	t.Cleanup(func() {
+		app.Shutdown()
+	})
+
+	return "ws://" + ln.Addr().String() + "/ws"
+}
+
+// dialConnection - open a client connection to url
+func dialConnection(t *testing.T, url string) *wsclient.Conn {
+	conn, _, err := wsclient.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	return conn
+}
+
+func TestServeConnectionMessages(t *testing.T) {
+	assert := assert.New(t)
+
+	msgChan := make(chan []byte, 1)
+	conn := dialConnection(t, startConnectionServer(t, msgChan))
+
+	msgChan <- []byte(`{"number":100}`)
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	messageType, frame, err := conn.ReadMessage()
+	assert.Nil(err)
+	assert.Equal(websocket.TextMessage, messageType)
+	assert.Equal(`{"number":100}`, string(frame))
+}
+
+func TestServeConnectionKeepalive(t *testing.T) {
+	assert := assert.New(t)
+
+	msgChan := make(chan []byte)
+	conn := dialConnection(t, startConnectionServer(t, msgChan))
+
+	// Pongs are sent by the default ping handler while reading
+	// NOTE the read times out since no message is sent
+	pings := 0
+	conn.SetPingHandler(func(appData string) error {
+		pings++
+		return conn.WriteControl(wsclient.PongMessage, []byte(appData), time.Now().Add(time.Second))
+	})
+	conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+	_, _, err := conn.ReadMessage()
+
+	netErr, ok := err.(net.Error)
+	if assert.True(ok, "connection closed while answering pings") {
+		assert.True(netErr.Timeout())
+	}
+	assert.GreaterOrEqual(pings, 2)
+}
+
+func TestServeConnectionIdleTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	msgChan := make(chan []byte)
+	conn := dialConnection(t, startConnectionServer(t, msgChan))
+
+	// Never send a pong
+	conn.SetPingHandler(func(string) error {
+		return nil
+	})
+
+	start := time.Now()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, _, err := conn.ReadMessage()
+	elapsed := time.Since(start)
+
+	// Closed by the server after WEBSOCKET_IDLE_TIMEOUT
+	assert.NotNil(err)
+	netErr, ok := err.(net.Error)
+	assert.False(ok && netErr.Timeout(), "connection not closed by the server")
+	assert.GreaterOrEqual(int64(elapsed), int64(time.Duration(config.Config.WebsocketIdleTimeout)*time.Second-100*time.Millisecond))
+}
+
+func TestServeConnectionCloseFrame(t *testing.T) {
+	assert := assert.New(t)
+
+	msgChan := make(chan []byte)
+	conn := dialConnection(t, startConnectionServer(t, msgChan))
+
+	// Removed by broadcaster
+	close(msgChan)
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, _, err := conn.ReadMessage()
+
+	closeErr, ok := err.(*wsclient.CloseError)
+	if assert.True(ok, "no close frame") {
+		assert.Equal(websocket.CloseTryAgainLater, closeErr.Code)
+		assert.Equal("slow consumer", closeErr.Text)
+	}
+}
@@ -0,0 +1,139 @@
+package ws
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/gofiber/websocket/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// Frame encodings
+// Negotiated through the websocket subprotocol or the format query parameter
+const (
+	encodingJSON     = "json"
+	encodingProtobuf = "protobuf"
+)
+
+// subprotocols - subprotocols offered to clients, in order of preference
+var subprotocols = []string{encodingProtobuf, encodingJSON}
+
+// frameEncoder - convert a JSON message from the broadcaster into a websocket frame
+type frameEncoder func(msg []byte) (messageType int, frame []byte, err error)
+
+// negotiateEncoding - frame encoding requested by the client, JSON by default
+func negotiateEncoding(c *websocket.Conn) string {
+	return selectEncoding(c.Subprotocol(), c.Query("format"))
+}
+
+func selectEncoding(subprotocol string, format string) string {
+	if subprotocol == encodingProtobuf || format == encodingProtobuf {
+		return encodingProtobuf
+	}
+
+	return encodingJSON
+}
+
+// protobufFrameCacheSize - number of recent broadcast messages with a cached protobuf frame
+const protobufFrameCacheSize = 16
+
+// protobufFrameEncoders - shared protobuf encoders by redis channel
+var (
+	protobufFrameEncoders   = map[string]frameEncoder{}
+	protobufFrameEncodersMu sync.Mutex
+)
+
+// newFrameEncoder - create an encoder for the encoding
+// newMessage returns an empty proto message of the type carried by the channel
+// NOTE protobuf encoders are shared by all subscribers of a channel
+func newFrameEncoder(encoding string, channel string, newMessage func() proto.Message) frameEncoder {
+	if encoding == encodingProtobuf {
+		return getProtobufFrameEncoder(channel, newMessage)
+	}
+
+	return jsonFrameEncoder
+}
+
+// getProtobufFrameEncoder - create and/or return the protobuf encoder for a redis channel
+func getProtobufFrameEncoder(channel string, newMessage func() proto.Message) frameEncoder {
+	protobufFrameEncodersMu.Lock()
+	defer protobufFrameEncodersMu.Unlock()
+
+	encoder, ok := protobufFrameEncoders[channel]
+	if !ok {
+		encoder = cachedFrameEncoder(protobufFrameEncoder(newMessage))
+		protobufFrameEncoders[channel] = encoder
+	}
+
+	return encoder
+}
+
+// jsonFrameEncoder - send messages as is in text frames
+func jsonFrameEncoder(msg []byte) (int, []byte, error) {
+	return websocket.TextMessage, msg, nil
+}
+
+// protobufFrameEncoder - send messages as binary frames of marshalled proto messages
+func protobufFrameEncoder(newMessage func() proto.Message) frameEncoder {
+	return func(msg []byte) (int, []byte, error) {
+		message := newMessage()
+
+		err := json.Unmarshal(msg, message)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		frame, err := proto.Marshal(message)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		return websocket.BinaryMessage, frame, nil
+	}
+}
+
+type cachedFrame struct {
+	msg         []byte
+	messageType int
+	frame       []byte
+}
+
+// cachedFrameEncoder - encode each broadcast message once and share the frame
+// NOTE the broadcaster sends the same slice to every subscriber, so messages are keyed by their backing array
+func cachedFrameEncoder(encoder frameEncoder) frameEncoder {
+	var (
+		mu     sync.Mutex
+		frames [protobufFrameCacheSize]cachedFrame
+		next   int
+	)
+
+	return func(msg []byte) (int, []byte, error) {
+		if len(msg) == 0 {
+			return encoder(msg)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		for i := range frames {
+			cached := &frames[i]
+			if len(cached.msg) == len(msg) && &cached.msg[0] == &msg[0] {
+				return cached.messageType, cached.frame, nil
+			}
+		}
+
+		messageType, frame, err := encoder(msg)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		frames[next] = cachedFrame{
+			msg:         msg,
+			messageType: messageType,
+			frame:       frame,
+		}
+		next = (next + 1) % protobufFrameCacheSize
+
+		return messageType, frame, nil
+	}
+}
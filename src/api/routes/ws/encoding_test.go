@@ -0,0 +1,99 @@
+package ws
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gofiber/websocket/v2"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/geometry-labs/icon-blocks/models"
+)
+
+func TestSelectEncoding(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(encodingJSON, selectEncoding("", ""))
+	assert.Equal(encodingJSON, selectEncoding(encodingJSON, ""))
+	assert.Equal(encodingProtobuf, selectEncoding(encodingProtobuf, ""))
+	assert.Equal(encodingProtobuf, selectEncoding("", encodingProtobuf))
+}
+
+func TestProtobufFrameEncoder(t *testing.T) {
+	assert := assert.New(t)
+
+	block := &models.BlockWebsocket{
+		Number:           100,
+		Hash:             "0xabc",
+		TransactionCount: 3,
+	}
+	msg, _ := json.Marshal(block)
+
+	encoder := newFrameEncoder(encodingProtobuf, "test-blocks", func() proto.Message {
+		return &models.BlockWebsocket{}
+	})
+
+	messageType, frame, err := encoder(msg)
+	assert.Equal(nil, err)
+	assert.Equal(websocket.BinaryMessage, messageType)
+
+	decoded := &models.BlockWebsocket{}
+	err = proto.Unmarshal(frame, decoded)
+	assert.Equal(nil, err)
+	assert.Equal(block.Number, decoded.Number)
+	assert.Equal(block.Hash, decoded.Hash)
+	assert.Equal(block.TransactionCount, decoded.TransactionCount)
+
+	// Invalid JSON
+	_, _, err = encoder([]byte("not json"))
+	assert.NotEqual(nil, err)
+}
+
+func TestJSONFrameEncoder(t *testing.T) {
+	assert := assert.New(t)
+
+	encoder := newFrameEncoder(encodingJSON, "test-blocks", nil)
+
+	messageType, frame, err := encoder([]byte(`{"number":1}`))
+	assert.Equal(nil, err)
+	assert.Equal(websocket.TextMessage, messageType)
+	assert.Equal([]byte(`{"number":1}`), frame)
+}
+
+func TestProtobufFrameEncoderShared(t *testing.T) {
+	assert := assert.New(t)
+
+	decodeCount := 0
+	newMessage := func() proto.Message {
+		decodeCount++
+		return &models.BlockWebsocket{}
+	}
+
+	// Subscribers of the same channel
+	first := newFrameEncoder(encodingProtobuf, "test-shared", newMessage)
+	second := newFrameEncoder(encodingProtobuf, "test-shared", newMessage)
+
+	msg, _ := json.Marshal(&models.BlockWebsocket{Number: 1})
+
+	_, firstFrame, err := first(msg)
+	assert.Equal(nil, err)
+	_, secondFrame, err := second(msg)
+	assert.Equal(nil, err)
+
+	// Encoded once, same bytes
+	assert.Equal(1, decodeCount)
+	assert.Equal(&firstFrame[0], &secondFrame[0])
+
+	// Next message
+	msg, _ = json.Marshal(&models.BlockWebsocket{Number: 2})
+
+	_, frame, err := second(msg)
+	assert.Equal(nil, err)
+	assert.Equal(2, decodeCount)
+
+	decoded := &models.BlockWebsocket{}
+	err = proto.Unmarshal(frame, decoded)
+	assert.Equal(nil, err)
+	assert.Equal(uint32(2), decoded.Number)
+}
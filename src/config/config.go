@@ -2,28 +2,36 @@ package config
 
 import (
 	"log"
+	"os"
 
 	"github.com/kelseyhightower/envconfig"
 )
 
 type configType struct {
 	Name        string `envconfig:"NAME" required:"false" default:"blocks-service"`
-	NetworkName string `envconfig:"NETWORK_NAME" required:"false" default:"mainnnet"`
+	NetworkName string `envconfig:"NETWORK_NAME" required:"false" default:"mainnet"`
 
 	// Ports
 	Port        string `envconfig:"PORT" required:"false" default:"8000"`
 	HealthPort  string `envconfig:"HEALTH_PORT" required:"false" default:"8180"`
 	MetricsPort string `envconfig:"METRICS_PORT" required:"false" default:"9400"`
+	GrpcPort    string `envconfig:"GRPC_PORT" required:"false" default:"50051"`
+	AdminPort   string `envconfig:"ADMIN_PORT" required:"false" default:"8280"`
 
 	// Prefix
-	RestPrefix      string `envconfig:"REST_PREFIX" required:"false" default:"/api/v1"`
-	WebsocketPrefix string `envconfig:"WEBSOCKET_PREFIX" required:"false" default:"/ws/v1"`
-	HealthPrefix    string `envconfig:"HEALTH_PREFIX" required:"false" default:"/health"`
-	MetricsPrefix   string `envconfig:"METRICS_PREFIX" required:"false" default:"/metrics"`
+	RestPrefix            string `envconfig:"REST_PREFIX" required:"false" default:"/api/v1"`
+	WebsocketPrefix       string `envconfig:"WEBSOCKET_PREFIX" required:"false" default:"/ws/v1"`
+	HealthPrefix          string `envconfig:"HEALTH_PREFIX" required:"false" default:"/health"`
+	HealthLivenessPrefix  string `envconfig:"HEALTH_LIVENESS_PREFIX" required:"false" default:"/health/live"`
+	HealthReadinessPrefix string `envconfig:"HEALTH_READINESS_PREFIX" required:"false" default:"/health/ready"`
+	MetricsPrefix         string `envconfig:"METRICS_PREFIX" required:"false" default:"/metrics"`
+	StatusPrefix          string `envconfig:"STATUS_PREFIX" required:"false" default:"/status"`
+	JSONRPCPath           string `envconfig:"JSONRPC_PATH" required:"false" default:"/api/v3"`
 
 	// Endpoints
-	MaxPageSize int `envconfig:"MAX_PAGE_SIZE" required:"false" default:"100"`
-	MaxPageSkip int `envconfig:"MAX_PAGE_SKIP" required:"false" default:"1000000"`
+	MaxPageSize         int `envconfig:"MAX_PAGE_SIZE" required:"false" default:"100"`
+	MaxPageSkip         int `envconfig:"MAX_PAGE_SKIP" required:"false" default:"1000000"`
+	JSONRPCMaxBatchSize int `envconfig:"JSONRPC_MAX_BATCH_SIZE" required:"false" default:"100"`
 
 	// CORS
 	CORSAllowOrigins  string `envconfig:"CORS_ALLOW_ORIGINS" required:"false" default:"*"`
@@ -34,8 +42,49 @@ type configType struct {
 	// Compress
 	RestCompressLevel int `envconfig:"REST_COMPRESS_LEVEL" required:"false" default:"2"`
 
+	// Proxy
+	// NOTE the proxy header is only read from requests sent by a trusted proxy IP
+	ProxyHeader    string   `envconfig:"PROXY_HEADER" required:"false" default:""`
+	TrustedProxies []string `envconfig:"TRUSTED_PROXIES" required:"false" default:""`
+
+	// Rate limits
+	// NOTE limits are requests per minute, 0 is unlimited
+	RateLimitEnabled         bool           `envconfig:"RATE_LIMIT_ENABLED" required:"false" default:"true"`
+	RateLimitIPPerMinute     int            `envconfig:"RATE_LIMIT_IP_PER_MINUTE" required:"false" default:"60"`
+	RateLimitTiers           map[string]int `envconfig:"RATE_LIMIT_TIERS" required:"false" default:"free:300,standard:1200,unlimited:0"`
+	RateLimitAPIKeyHeader    string         `envconfig:"RATE_LIMIT_API_KEY_HEADER" required:"false" default:"X-API-Key"`
+	RateLimitAPIKeyCacheTime int            `envconfig:"RATE_LIMIT_API_KEY_CACHE_TIME" required:"false" default:"60"`
+
+	// GraphQL
+	GraphQLMaxDepth      int `envconfig:"GRAPHQL_MAX_DEPTH" required:"false" default:"5"`
+	GraphQLMaxComplexity int `envconfig:"GRAPHQL_MAX_COMPLEXITY" required:"false" default:"5000"`
+
+	// Websockets
+	WebsocketPingInterval int `envconfig:"WEBSOCKET_PING_INTERVAL" required:"false" default:"30"`
+	WebsocketIdleTimeout  int `envconfig:"WEBSOCKET_IDLE_TIMEOUT" required:"false" default:"75"`
+	WebsocketWriteTimeout int `envconfig:"WEBSOCKET_WRITE_TIMEOUT" required:"false" default:"10"`
+
+	// Server-Sent Events
+	SSEKeepaliveInterval int `envconfig:"SSE_KEEPALIVE_INTERVAL" required:"false" default:"15"`
+	SSEReplayLimit       int `envconfig:"SSE_REPLAY_LIMIT" required:"false" default:"1000"`
+
 	// Monitoring
 	HealthPollingInterval int `envconfig:"HEALTH_POLLING_INTERVAL" required:"false" default:"10"`
+	HealthCheckTimeout    int `envconfig:"HEALTH_CHECK_TIMEOUT" required:"false" default:"5"`
+	HealthFreshnessMaxAge int `envconfig:"HEALTH_FRESHNESS_MAX_AGE" required:"false" default:"120"`
+
+	// Health check severities, overriding the defaults
+	// NOTE check:severity pairs, severity is one of liveness, readiness or info
+	HealthCheckSeverities map[string]string `envconfig:"HEALTH_CHECK_SEVERITIES" required:"false" default:""`
+
+	// Derived table lag thresholds, failing readiness when exceeded
+	// NOTE table:max pairs, tables without a threshold never fail
+	StatusMaxBlocksBehind  map[string]int `envconfig:"STATUS_MAX_BLOCKS_BEHIND" required:"false" default:""`
+	StatusMaxSecondsBehind map[string]int `envconfig:"STATUS_MAX_SECONDS_BEHIND" required:"false" default:""`
+
+	// Admin
+	// NOTE requests must send the token as a bearer token, the server is disabled without one
+	AdminToken string `envconfig:"ADMIN_TOKEN" required:"false" default:"" secret:"true"`
 
 	// Logging
 	LogLevel         string `envconfig:"LOG_LEVEL" required:"false" default:"INFO"`
@@ -44,12 +93,49 @@ type configType struct {
 	LogFormat        string `envconfig:"LOG_FORMAT" required:"false" default:"json"`
 	LogIsDevelopment bool   `envconfig:"LOG_IS_DEVELOPMENT" required:"false" default:"true"`
 
+	// Access logs
+	// NOTE per second, the first AccessLogSampleInitial requests are logged, then every AccessLogSampleThereafter-th
+	AccessLogSampleInitial    int `envconfig:"ACCESS_LOG_SAMPLE_INITIAL" required:"false" default:"100"`
+	AccessLogSampleThereafter int `envconfig:"ACCESS_LOG_SAMPLE_THEREAFTER" required:"false" default:"100"`
+
+	// Tracing
+	// NOTE exporter is one of none, otlp, stdout or file
+	TracingExporter     string  `envconfig:"TRACING_EXPORTER" required:"false" default:"none"`
+	TracingOTLPEndpoint string  `envconfig:"TRACING_OTLP_ENDPOINT" required:"false" default:"otel-collector:4317"`
+	TracingOTLPInsecure bool    `envconfig:"TRACING_OTLP_INSECURE" required:"false" default:"true"`
+	TracingFileName     string  `envconfig:"TRACING_FILE_NAME" required:"false" default:"blocks-service-traces.json"`
+	TracingSampleRatio  float64 `envconfig:"TRACING_SAMPLE_RATIO" required:"false" default:"1"`
+
+	// Worker source
+	// NOTE source is one of kafka or file, file replays SOURCE_FILES as topic:path pairs
+	WorkerSource string            `envconfig:"WORKER_SOURCE" required:"false" default:"kafka"`
+	SourceFiles  map[string]string `envconfig:"SOURCE_FILES" required:"false" default:""`
+
 	// Kafka
 	KafkaBrokerURL    string `envconfig:"KAFKA_BROKER_URL" required:"false" default:"kafka:9092"`
 	SchemaRegistryURL string `envconfig:"SCHEMA_REGISTRY_URL" required:"false" default:"schemaregistry:8081"`
 	KafkaGroupID      string `envconfig:"KAFKA_GROUP_ID" required:"false" default:"blocks-service"`
 	KafkaCreateTopic  bool   `envconfig:"KAFKA_CREATE_TOPIC" required:"false" default:"false"`
 
+	// Kafka brokers and protocol
+	// NOTE KAFKA_BROKERS takes precedence over KAFKA_BROKER_URL when set
+	KafkaBrokers []string `envconfig:"KAFKA_BROKERS" required:"false" default:""`
+	KafkaVersion string   `envconfig:"KAFKA_VERSION" required:"false" default:"2.1.1"`
+
+	// Kafka TLS
+	// NOTE a client certificate needs both the cert and key files
+	KafkaTLSEnabled            bool   `envconfig:"KAFKA_TLS_ENABLED" required:"false" default:"false"`
+	KafkaTLSCAFile             string `envconfig:"KAFKA_TLS_CA_FILE" required:"false" default:""`
+	KafkaTLSCertFile           string `envconfig:"KAFKA_TLS_CERT_FILE" required:"false" default:""`
+	KafkaTLSKeyFile            string `envconfig:"KAFKA_TLS_KEY_FILE" required:"false" default:""`
+	KafkaTLSInsecureSkipVerify bool   `envconfig:"KAFKA_TLS_INSECURE_SKIP_VERIFY" required:"false" default:"false"`
+
+	// Kafka SASL
+	// NOTE mechanism is one of none, PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512
+	KafkaSASLMechanism string `envconfig:"KAFKA_SASL_MECHANISM" required:"false" default:"none"`
+	KafkaSASLUsername  string `envconfig:"KAFKA_SASL_USERNAME" required:"false" default:""`
+	KafkaSASLPassword  string `envconfig:"KAFKA_SASL_PASSWORD" required:"false" default:"" secret:"true"`
+
 	// Topics
 	ConsumerGroup                string            `envconfig:"CONSUMER_GROUP" required:"false" default:"blocks-consumer-group"`
 	ConsumerIsTail               bool              `envconfig:"CONSUMER_IS_TAIL" required:"false" default:"false"`
@@ -62,7 +148,8 @@ type configType struct {
 	ConsumerPartition            int               `envconfig:"CONSUMER_PARTITION" required:"false" default:"0"`
 	ConsumerPartitionTopic       string            `envconfig:"CONSUMER_PARTITION_TOPIC" required:"false" default:"blocks"`
 	ConsumerPartitionStartOffset int               `envconfig:"CONSUMER_PARTITION_START_OFFSET" required:"false" default:"1"`
-	ProducerTopics               []string          `envconfig:"CONSUMER_TOPIC_NAME_LOGS" required:"false" default:""`
+	ProducerTopics               []string          `envconfig:"PRODUCER_TOPICS" required:"false" default:""`
+	ProducerLogsLag              int               `envconfig:"PRODUCER_LOGS_LAG" required:"false" default:"10"`
 	SchemaNameTopics             map[string]string `envconfig:"SCHEMA_NAME_TOPICS" required:"false" default:"blocks-ws:block"`
 	SchemaFolderPath             string            `envconfig:"SCHEMA_FOLDER_PATH" required:"false" default:"/app/schemas/"`
 
@@ -71,20 +158,32 @@ type configType struct {
 	DbHost               string `envconfig:"DB_HOST" required:"false" default:"localhost"`
 	DbPort               string `envconfig:"DB_PORT" required:"false" default:"5432"`
 	DbUser               string `envconfig:"DB_USER" required:"false" default:"postgres"`
-	DbPassword           string `envconfig:"DB_PASSWORD" required:"false" default:"changeme"`
+	DbPassword           string `envconfig:"DB_PASSWORD" required:"false" default:"changeme" secret:"true"`
 	DbName               string `envconfig:"DB_DBNAME" required:"false" default:"postgres"`
 	DbSslmode            string `envconfig:"DB_SSL_MODE" required:"false" default:"disable"`
 	DbTimezone           string `envconfig:"DB_TIMEZONE" required:"false" default:"UTC"`
 	DbMaxIdleConnections int    `envconfig:"DB_MAX_IDLE_CONNECTIONS" required:"false" default:"2"`
 	DbMaxOpenConnections int    `envconfig:"DB_MAX_OPEN_CONNECTIONS" required:"false" default:"10"`
 
+	// DB query guard
+	DbStatementTimeoutMilli int `envconfig:"DB_STATEMENT_TIMEOUT_MILLI" required:"false" default:"5000"`
+	DbMaxSkipWithoutRange   int `envconfig:"DB_MAX_SKIP_WITHOUT_RANGE" required:"false" default:"10000"`
+
 	// Redis
-	RedisHost                     string `envconfig:"REDIS_HOST" required:"false" default:"redis"`
-	RedisPort                     string `envconfig:"REDIS_PORT" required:"false" default:"6380"`
-	RedisPassword                 string `envconfig:"REDIS_PASSWORD" required:"false" default:""`
-	RedisChannel                  string `envconfig:"REDIS_CHANNEL" required:"false" default:"blocks"`
-	RedisSentinelClientMode       bool   `envconfig:"REDIS_SENTINEL_CLIENT_MODE" required:"false" default:"false"`
-	RedisSentinelClientMasterName string `envconfig:"REDIS_SENTINEL_CLIENT_MASTER_NAME" required:"false" default:"master"`
+	RedisHost                             string `envconfig:"REDIS_HOST" required:"false" default:"redis"`
+	RedisPort                             string `envconfig:"REDIS_PORT" required:"false" default:"6380"`
+	RedisPassword                         string `envconfig:"REDIS_PASSWORD" required:"false" default:"" secret:"true"`
+	RedisChannel                          string `envconfig:"REDIS_CHANNEL" required:"false" default:"blocks"`
+	RedisChannelBlockTransactions         string `envconfig:"REDIS_CHANNEL_BLOCK_TRANSACTIONS" required:"false" default:"block-transactions"`
+	RedisChannelBlockInternalTransactions string `envconfig:"REDIS_CHANNEL_BLOCK_INTERNAL_TRANSACTIONS" required:"false" default:"block-internal-transactions"`
+	RedisChannelBlockFailedTransactions   string `envconfig:"REDIS_CHANNEL_BLOCK_FAILED_TRANSACTIONS" required:"false" default:"block-failed-transactions"`
+	RedisSentinelClientMode               bool   `envconfig:"REDIS_SENTINEL_CLIENT_MODE" required:"false" default:"false"`
+	RedisSentinelClientMasterName         string `envconfig:"REDIS_SENTINEL_CLIENT_MASTER_NAME" required:"false" default:"master"`
+
+	// Broadcaster
+	BroadcasterShards             int    `envconfig:"BROADCASTER_SHARDS" required:"false" default:"16"`
+	BroadcasterBufferSize         int    `envconfig:"BROADCASTER_BUFFER_SIZE" required:"false" default:"32"`
+	BroadcasterSlowConsumerPolicy string `envconfig:"BROADCASTER_SLOW_CONSUMER_POLICY" required:"false" default:"drop"`
 
 	// GORM
 	GormLoggingThresholdMilli int `envconfig:"GORM_LOGGING_THRESHOLD_MILLI" required:"false" default:"250"`
@@ -98,9 +197,26 @@ type configType struct {
 var Config configType
 
 // ReadEnvironment - Read and store runtime config
+// Layers, lowest first: defaults, CONFIG_FILE, env vars, *_FILE secrets
 func ReadEnvironment() {
-	err := envconfig.Process("", &Config)
+	// NOTE the config file sets env vars, so is read before envconfig
+	err := readConfigFile(os.Getenv("CONFIG_FILE"))
+	if err != nil {
+		log.Fatalf("ERROR: config file - %s\n", err.Error())
+	}
+
+	err = envconfig.Process("", &Config)
 	if err != nil {
 		log.Fatalf("ERROR: envconfig - %s\n", err.Error())
 	}
+
+	err = readSecretFiles(&Config)
+	if err != nil {
+		log.Fatalf("ERROR: secret files - %s\n", err.Error())
+	}
+
+	err = validate(&Config)
+	if err != nil {
+		log.Fatalf("ERROR: invalid config - %s\n", err.Error())
+	}
 }
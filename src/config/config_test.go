@@ -14,17 +14,17 @@ func TestEnvironment(t *testing.T) {
 	// Set env
 	envMap := map[string]string{
 		"NAME":                    "name",
-		"PORT":                    "port",
-		"HEALTH_PORT":             "health_port",
-		"METRICS_PORT":            "metrics_port",
-		"REST_PREFIX":             "rest_prefix",
-		"WEBSOCKET_PREFIX":        "websocket_prefix",
-		"HEALTH_PREFIX":           "health_prefix",
-		"METRICS_PREFIX":          "metrics_prefix",
+		"PORT":                    "8001",
+		"HEALTH_PORT":             "8181",
+		"METRICS_PORT":            "9401",
+		"REST_PREFIX":             "/rest_prefix",
+		"WEBSOCKET_PREFIX":        "/websocket_prefix",
+		"HEALTH_PREFIX":           "/health_prefix",
+		"METRICS_PREFIX":          "/metrics_prefix",
 		"HEALTH_POLLING_INTERVAL": "5",
-		"LOG_LEVEL":               "log_level",
+		"LOG_LEVEL":               "WARN",
 		"LOG_TO_FILE":             "true",
-		"NETWORK_NAME":            "network_name",
+		"NETWORK_NAME":            "sejong",
 		"KAFKA_BROKER_URL":        "kafka_broker_url",
 		"SCHEMA_REGISTRY_URL":     "schema_registry_url",
 		"KAFKA_GROUP_ID":          "kafka_group_id",
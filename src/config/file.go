@@ -0,0 +1,148 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// readConfigFile - set env vars from a yaml or toml file
+// NOTE keys are env var names, e.g. DB_HOST: postgres, env vars already set take precedence
+func readConfigFile(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	values, err := parseConfigFile(filepath.Ext(path), contents)
+	if err != nil {
+		return fmt.Errorf("%s: %s", path, err.Error())
+	}
+
+	for key, value := range values {
+		if _, ok := os.LookupEnv(key); ok {
+			continue
+		}
+
+		os.Setenv(key, value)
+	}
+
+	return nil
+}
+
+// parseConfigFile - env var values in a config file, by env var name
+func parseConfigFile(extension string, contents []byte) (map[string]string, error) {
+	values := map[string]interface{}{}
+
+	var err error
+	switch strings.ToLower(extension) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(contents, &values)
+	case ".toml":
+		_, err = toml.Decode(string(contents), &values)
+	default:
+		err = fmt.Errorf("unknown config file type %q, expected .yaml, .yml or .toml", extension)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	knownKeys := envKeys()
+
+	errors := ValidationError{}
+	envValues := make(map[string]string, len(values))
+	for key, value := range values {
+		key = strings.ToUpper(key)
+
+		if !knownKeys[key] {
+			errors = append(errors, "unknown key "+key)
+			continue
+		}
+
+		envValue, err := toEnvValue(value)
+		if err != nil {
+			errors = append(errors, key+": "+err.Error())
+			continue
+		}
+
+		envValues[key] = envValue
+	}
+
+	if len(errors) > 0 {
+		return nil, errors
+	}
+
+	return envValues, nil
+}
+
+// toEnvValue - value in the format read by envconfig
+// Lists are comma separated, maps are comma separated key:value pairs
+func toEnvValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			if !isScalar(item) {
+				return "", fmt.Errorf("lists can only hold plain values")
+			}
+			items[i] = fmt.Sprint(item)
+		}
+
+		return strings.Join(items, ","), nil
+	case map[string]interface{}:
+		pairs := make([]string, 0, len(v))
+		for key, item := range v {
+			if !isScalar(item) {
+				return "", fmt.Errorf("maps can only hold plain values")
+			}
+			pairs = append(pairs, key+":"+fmt.Sprint(item))
+		}
+		sort.Strings(pairs)
+
+		return strings.Join(pairs, ","), nil
+	}
+
+	if !isScalar(value) {
+		return "", fmt.Errorf("unsupported value %v", value)
+	}
+
+	return fmt.Sprint(value), nil
+}
+
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case string, bool, int, int64, uint64, float64:
+		return true
+	}
+
+	return false
+}
+
+// envKeys - env var names read into the config, including secret file names
+func envKeys() map[string]bool {
+	fields := reflect.TypeOf(configType{})
+
+	keys := make(map[string]bool, fields.NumField())
+	for i := 0; i < fields.NumField(); i++ {
+		field := fields.Field(i)
+		key := field.Tag.Get("envconfig")
+
+		keys[key] = true
+		if field.Tag.Get("secret") == "true" {
+			keys[key+secretFileSuffix] = true
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"reflect"
+)
+
+const redactedValue = "REDACTED"
+
+// Redacted - runtime config keyed by env var, secret values replaced
+// NOTE fields tagged secret:"true" are redacted, empty secrets are left empty to show they are unset
+func Redacted() map[string]interface{} {
+	return redact(Config)
+}
+
+func redact(config configType) map[string]interface{} {
+	values := reflect.ValueOf(config)
+	fields := values.Type()
+
+	redacted := make(map[string]interface{}, fields.NumField())
+	for i := 0; i < fields.NumField(); i++ {
+		field := fields.Field(i)
+		value := values.Field(i).Interface()
+
+		if field.Tag.Get("secret") == "true" && !values.Field(i).IsZero() {
+			value = redactedValue
+		}
+
+		redacted[field.Tag.Get("envconfig")] = value
+	}
+
+	return redacted
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	assert := assert.New(t)
+
+	config := configType{
+		Name:          "blocks-service",
+		DbPassword:    "changeme",
+		RedisPassword: "",
+		AdminToken:    "token",
+	}
+
+	redacted := redact(config)
+
+	// Keyed by env var
+	assert.Equal("blocks-service", redacted["NAME"])
+
+	// Secrets
+	assert.Equal("REDACTED", redacted["DB_PASSWORD"])
+	assert.Equal("REDACTED", redacted["ADMIN_TOKEN"])
+
+	// Unset secrets stay empty
+	assert.Equal("", redacted["REDIS_PASSWORD"])
+}
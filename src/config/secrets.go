@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// secretFileSuffix - env var suffix naming a file holding a secret
+// e.g. DB_PASSWORD_FILE=/run/secrets/db_password
+const secretFileSuffix = "_FILE"
+
+// readSecretFiles - read fields tagged secret:"true" from their *_FILE env var, if set
+// NOTE a secret file takes precedence over the plain env var
+func readSecretFiles(config *configType) error {
+	values := reflect.ValueOf(config).Elem()
+	fields := values.Type()
+
+	errors := ValidationError{}
+	for i := 0; i < fields.NumField(); i++ {
+		field := fields.Field(i)
+		if field.Tag.Get("secret") != "true" {
+			continue
+		}
+
+		fileKey := field.Tag.Get("envconfig") + secretFileSuffix
+		path := os.Getenv(fileKey)
+		if path == "" {
+			continue
+		}
+
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %s", fileKey, err.Error()))
+			continue
+		}
+
+		// NOTE secret files usually end with a newline
+		values.Field(i).SetString(strings.TrimRight(string(contents), "\r\n"))
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// networkNames - ICON networks the service can index
+var networkNames = []string{"mainnet", "lisbon", "berlin", "sejong", "euljiro", "yeouido", "local"}
+
+// ValidationError - every invalid config value, reported at once
+type ValidationError []string
+
+func (e ValidationError) Error() string {
+	return strings.Join(e, "; ")
+}
+
+func (e *ValidationError) add(format string, args ...interface{}) {
+	*e = append(*e, fmt.Sprintf(format, args...))
+}
+
+// oneOf - value must be one of allowed
+func (e *ValidationError) oneOf(key string, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+
+	e.add("%s must be one of %s, got %q", key, strings.Join(allowed, ", "), value)
+}
+
+// positive - value must be above 0
+func (e *ValidationError) positive(key string, value int) {
+	if value <= 0 {
+		e.add("%s must be positive, got %d", key, value)
+	}
+}
+
+// keyValue - config value with its env var name
+type keyValue struct {
+	key   string
+	value string
+}
+
+// validate - check config values
+// NOTE only values with a fixed set of meanings are checked
+func validate(config *configType) error {
+	errors := ValidationError{}
+
+	errors.oneOf("NETWORK_NAME", config.NetworkName, networkNames...)
+
+	// Ports
+	ports := map[string]string{}
+	for _, port := range []keyValue{
+		{"PORT", config.Port},
+		{"HEALTH_PORT", config.HealthPort},
+		{"METRICS_PORT", config.MetricsPort},
+		{"GRPC_PORT", config.GrpcPort},
+		{"ADMIN_PORT", config.AdminPort},
+	} {
+		number, err := strconv.Atoi(port.value)
+		if err != nil || number < 1 || number > 65535 {
+			errors.add("%s must be a port number, got %q", port.key, port.value)
+			continue
+		}
+
+		if other, ok := ports[port.value]; ok {
+			errors.add("%s and %s are both %s", other, port.key, port.value)
+		}
+		ports[port.value] = port.key
+	}
+
+	// Prefix
+	for _, prefix := range []keyValue{
+		{"REST_PREFIX", config.RestPrefix},
+		{"WEBSOCKET_PREFIX", config.WebsocketPrefix},
+		{"HEALTH_PREFIX", config.HealthPrefix},
+		{"HEALTH_LIVENESS_PREFIX", config.HealthLivenessPrefix},
+		{"HEALTH_READINESS_PREFIX", config.HealthReadinessPrefix},
+		{"METRICS_PREFIX", config.MetricsPrefix},
+		{"STATUS_PREFIX", config.StatusPrefix},
+		{"JSONRPC_PATH", config.JSONRPCPath},
+	} {
+		if !strings.HasPrefix(prefix.value, "/") {
+			errors.add("%s must start with /, got %q", prefix.key, prefix.value)
+		}
+	}
+
+	// Endpoints
+	errors.positive("MAX_PAGE_SIZE", config.MaxPageSize)
+	errors.positive("JSONRPC_MAX_BATCH_SIZE", config.JSONRPCMaxBatchSize)
+
+	// Websockets and Server-Sent Events
+	// NOTE intervals are passed to time.NewTicker, which panics when not positive
+	errors.positive("WEBSOCKET_PING_INTERVAL", config.WebsocketPingInterval)
+	errors.positive("WEBSOCKET_IDLE_TIMEOUT", config.WebsocketIdleTimeout)
+	errors.positive("WEBSOCKET_WRITE_TIMEOUT", config.WebsocketWriteTimeout)
+	errors.positive("SSE_KEEPALIVE_INTERVAL", config.SSEKeepaliveInterval)
+
+	// Proxy
+	if config.ProxyHeader != "" && len(config.TrustedProxies) == 0 {
+		errors.add("TRUSTED_PROXIES is required by PROXY_HEADER %s", config.ProxyHeader)
+	}
+	for _, proxy := range config.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			errors.add("TRUSTED_PROXIES must be IP addresses, got %q", proxy)
+		}
+	}
+
+	// Monitoring
+	errors.positive("HEALTH_POLLING_INTERVAL", config.HealthPollingInterval)
+	errors.positive("HEALTH_CHECK_TIMEOUT", config.HealthCheckTimeout)
+	checks := make([]string, 0, len(config.HealthCheckSeverities))
+	for check := range config.HealthCheckSeverities {
+		checks = append(checks, check)
+	}
+	sort.Strings(checks)
+	for _, check := range checks {
+		errors.oneOf("HEALTH_CHECK_SEVERITIES "+check, config.HealthCheckSeverities[check], "liveness", "readiness", "info")
+	}
+
+	// Logging
+	errors.oneOf("LOG_LEVEL", strings.ToUpper(config.LogLevel), "PANIC", "FATAL", "ERROR", "WARN", "INFO", "DEBUG")
+	errors.oneOf("LOG_FORMAT", config.LogFormat, "json", "console")
+
+	// Tracing
+	errors.oneOf("TRACING_EXPORTER", strings.ToLower(config.TracingExporter), "none", "otlp", "stdout", "file")
+	if config.TracingSampleRatio < 0 || config.TracingSampleRatio > 1 {
+		errors.add("TRACING_SAMPLE_RATIO must be between 0 and 1, got %v", config.TracingSampleRatio)
+	}
+
+	// Topics
+	errors.oneOf(
+		"CONSUMER_GROUP_BALANCE_STRATEGY",
+		config.ConsumerGroupBalanceStrategy,
+		"BalanceStrategyRange", "BalanceStrategySticky", "BalanceStrategyRoundRobin",
+	)
+	if config.ConsumerIsPartitionConsumer == true && config.ConsumerPartition < 0 {
+		errors.add("CONSUMER_PARTITION must not be negative, got %d", config.ConsumerPartition)
+	}
+
+	// Worker source
+	errors.oneOf("WORKER_SOURCE", config.WorkerSource, "kafka", "file")
+	if config.WorkerSource == "file" && len(config.SourceFiles) == 0 {
+		errors.add("SOURCE_FILES is required by WORKER_SOURCE file")
+	}
+
+	// Producer
+	errors.positive("PRODUCER_LOGS_LAG", config.ProducerLogsLag)
+	for _, topic := range config.ProducerTopics {
+		if _, ok := config.SchemaNameTopics[topic]; !ok {
+			errors.add("PRODUCER_TOPICS topic %s has no schema name in SCHEMA_NAME_TOPICS", topic)
+		}
+	}
+
+	// Kafka TLS
+	if (config.KafkaTLSCertFile == "") != (config.KafkaTLSKeyFile == "") {
+		errors.add("KAFKA_TLS_CERT_FILE and KAFKA_TLS_KEY_FILE must be set together")
+	}
+	if config.KafkaTLSEnabled == false &&
+		(config.KafkaTLSCAFile != "" || config.KafkaTLSCertFile != "" || config.KafkaTLSInsecureSkipVerify == true) {
+		errors.add("KAFKA_TLS_* options are set but KAFKA_TLS_ENABLED is false")
+	}
+
+	// Kafka SASL
+	errors.oneOf("KAFKA_SASL_MECHANISM", config.KafkaSASLMechanism, "none", "PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512")
+	if config.KafkaSASLMechanism != "none" && (config.KafkaSASLUsername == "" || config.KafkaSASLPassword == "") {
+		errors.add("KAFKA_SASL_USERNAME and KAFKA_SASL_PASSWORD are required by KAFKA_SASL_MECHANISM %s", config.KafkaSASLMechanism)
+	}
+
+	// DB
+	errors.oneOf("DB_DRIVER", config.DbDriver, "postgres")
+	errors.positive("DB_MAX_OPEN_CONNECTIONS", config.DbMaxOpenConnections)
+	errors.positive("DB_STATEMENT_TIMEOUT_MILLI", config.DbStatementTimeoutMilli)
+
+	// Broadcaster
+	errors.positive("BROADCASTER_SHARDS", config.BroadcasterShards)
+	errors.positive("BROADCASTER_BUFFER_SIZE", config.BroadcasterBufferSize)
+	errors.oneOf("BROADCASTER_SLOW_CONSUMER_POLICY", strings.ToLower(config.BroadcasterSlowConsumerPolicy), "drop", "disconnect")
+
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+// defaultConfig - config read from defaults and the test env
+func defaultConfig(t *testing.T) *configType {
+	config := &configType{}
+
+	err := envconfig.Process("", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return config
+}
+
+func TestValidateDefaults(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(validate(defaultConfig(t)))
+}
+
+func TestValidateAggregatesErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	config := defaultConfig(t)
+	config.NetworkName = "mainnnet"
+	config.ConsumerGroupBalanceStrategy = "BalanceStrategyRandom"
+	config.HealthPort = "8180"
+	config.MetricsPort = "8180"
+	config.LogLevel = "verbose"
+
+	err := validate(config)
+	assert.NotNil(err)
+
+	errors, ok := err.(ValidationError)
+	assert.True(ok)
+	assert.Len(errors, 4)
+	assert.Contains(err.Error(), `NETWORK_NAME must be one of`)
+	assert.Contains(err.Error(), `CONSUMER_GROUP_BALANCE_STRATEGY must be one of`)
+	assert.Contains(err.Error(), `HEALTH_PORT and METRICS_PORT are both 8180`)
+	assert.Contains(err.Error(), `LOG_LEVEL must be one of`)
+}
+
+func TestParseConfigFile(t *testing.T) {
+	assert := assert.New(t)
+
+	yamlFile := []byte(`
+DB_HOST: postgres
+db_port: 5433
+KAFKA_CREATE_TOPIC: true
+PRODUCER_TOPICS:
+  - blocks-enriched
+  - blocks-ws
+SCHEMA_NAME_TOPICS:
+  blocks-ws: block
+  blocks-enriched: block
+`)
+
+	values, err := parseConfigFile(".yaml", yamlFile)
+	assert.Nil(err)
+	assert.Equal("postgres", values["DB_HOST"])
+	assert.Equal("5433", values["DB_PORT"])
+	assert.Equal("true", values["KAFKA_CREATE_TOPIC"])
+	assert.Equal("blocks-enriched,blocks-ws", values["PRODUCER_TOPICS"])
+	assert.Equal("blocks-enriched:block,blocks-ws:block", values["SCHEMA_NAME_TOPICS"])
+
+	tomlFile := []byte(`
+DB_HOST = "postgres"
+DB_MAX_OPEN_CONNECTIONS = 20
+DB_PASSWORD_FILE = "/run/secrets/db_password"
+`)
+
+	values, err = parseConfigFile(".toml", tomlFile)
+	assert.Nil(err)
+	assert.Equal("postgres", values["DB_HOST"])
+	assert.Equal("20", values["DB_MAX_OPEN_CONNECTIONS"])
+	assert.Equal("/run/secrets/db_password", values["DB_PASSWORD_FILE"])
+
+	// Unknown keys
+	_, err = parseConfigFile(".yaml", []byte("DB_HOTS: postgres"))
+	assert.EqualError(err, "unknown key DB_HOTS")
+
+	// Unknown file type
+	_, err = parseConfigFile(".json", []byte("{}"))
+	assert.NotNil(err)
+}
+
+func TestReadSecretFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "secrets")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "db_password")
+	assert.Nil(ioutil.WriteFile(path, []byte("s3cret\n"), 0600))
+
+	os.Setenv("DB_PASSWORD_FILE", path)
+	defer os.Unsetenv("DB_PASSWORD_FILE")
+
+	config := &configType{DbPassword: "changeme"}
+	assert.Nil(readSecretFiles(config))
+	assert.Equal("s3cret", config.DbPassword)
+
+	// Missing file
+	os.Setenv("DB_PASSWORD_FILE", filepath.Join(dir, "missing"))
+	assert.NotNil(readSecretFiles(config))
+}
+
+func TestValidateKafkaSecurity(t *testing.T) {
+	assert := assert.New(t)
+
+	config := defaultConfig(t)
+	config.KafkaSASLMechanism = "SCRAM-SHA-256"
+	config.KafkaTLSCertFile = "/certs/client.pem"
+
+	err := validate(config)
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "KAFKA_TLS_CERT_FILE and KAFKA_TLS_KEY_FILE must be set together")
+	assert.Contains(err.Error(), "KAFKA_TLS_* options are set but KAFKA_TLS_ENABLED is false")
+	assert.Contains(err.Error(), "KAFKA_SASL_USERNAME and KAFKA_SASL_PASSWORD are required")
+
+	config.KafkaTLSEnabled = true
+	config.KafkaTLSKeyFile = "/certs/client.key"
+	config.KafkaSASLUsername = "user"
+	config.KafkaSASLPassword = "password"
+	assert.Nil(validate(config))
+
+	config.KafkaSASLMechanism = "GSSAPI"
+	assert.NotNil(validate(config))
+}
+
+func TestValidateProducerTopics(t *testing.T) {
+	assert := assert.New(t)
+
+	config := defaultConfig(t)
+	config.ProducerTopics = []string{"blocks-ws", "blocks-enriched"}
+	config.SchemaNameTopics = map[string]string{"blocks-ws": "block"}
+
+	err := validate(config)
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "PRODUCER_TOPICS topic blocks-enriched has no schema name in SCHEMA_NAME_TOPICS")
+
+	config.SchemaNameTopics["blocks-enriched"] = "block"
+	assert.Nil(validate(config))
+}
+
+func TestValidateProxy(t *testing.T) {
+	assert := assert.New(t)
+
+	// Header without trusted proxies
+	config := defaultConfig(t)
+	config.ProxyHeader = "X-Forwarded-For"
+	err := validate(config)
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "TRUSTED_PROXIES is required by PROXY_HEADER")
+
+	// Invalid address
+	config.TrustedProxies = []string{"10.0.0.1", "proxy.local"}
+	err = validate(config)
+	assert.NotNil(err)
+	assert.Contains(err.Error(), `TRUSTED_PROXIES must be IP addresses, got "proxy.local"`)
+
+	config.TrustedProxies = []string{"10.0.0.1"}
+	assert.Nil(validate(config))
+}
+
+func TestValidateIntervals(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, c := range []struct {
+		key string
+		set func(config *configType)
+	}{
+		{"WEBSOCKET_PING_INTERVAL", func(config *configType) { config.WebsocketPingInterval = 0 }},
+		{"WEBSOCKET_IDLE_TIMEOUT", func(config *configType) { config.WebsocketIdleTimeout = -1 }},
+		{"WEBSOCKET_WRITE_TIMEOUT", func(config *configType) { config.WebsocketWriteTimeout = 0 }},
+		{"SSE_KEEPALIVE_INTERVAL", func(config *configType) { config.SSEKeepaliveInterval = 0 }},
+		{"DB_STATEMENT_TIMEOUT_MILLI", func(config *configType) { config.DbStatementTimeoutMilli = 0 }},
+	} {
+		config := defaultConfig(t)
+		c.set(config)
+
+		err := validate(config)
+		assert.NotNil(err, c.key)
+		if err != nil {
+			assert.Contains(err.Error(), c.key+" must be positive")
+		}
+	}
+}
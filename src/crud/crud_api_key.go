@@ -0,0 +1,71 @@
+package crud
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-blocks/models"
+)
+
+// ApiKeyModel - type for apiKeys table model
+type ApiKeyModel struct {
+	db       *gorm.DB
+	model    *models.ApiKey
+	modelORM *models.ApiKeyORM
+}
+
+var apiKeyModel *ApiKeyModel
+var apiKeyModelOnce sync.Once
+
+// GetApiKeyModel - create and/or return the apiKeys table model
+func GetApiKeyModel() *ApiKeyModel {
+	apiKeyModelOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		apiKeyModel = &ApiKeyModel{
+			db:       dbConn,
+			model:    &models.ApiKey{},
+			modelORM: &models.ApiKeyORM{},
+		}
+
+		err := apiKeyModel.Migrate()
+		if err != nil {
+			zap.S().Fatal("ApiKeyModel: Unable migrate postgres table: ", err.Error())
+		}
+	})
+
+	return apiKeyModel
+}
+
+// Migrate - migrate apiKeys table
+func (m *ApiKeyModel) Migrate() error {
+	// Only using ApiKeyORM (ORM version of the proto generated struct) to create the TABLE
+	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	return err
+}
+
+// SelectOne - select from apiKeys table by key hash
+func (m *ApiKeyModel) SelectOne(
+	ctx context.Context,
+	keyHash string,
+) (*models.ApiKey, error) {
+	query := startRead(ctx, "ApiKeyModel.SelectOne")
+	db := query.session(m.db)
+
+	// Set table
+	db = db.Model(&models.ApiKey{})
+
+	// Key hash
+	db = db.Where("key_hash = ?", keyHash)
+
+	apiKey := &models.ApiKey{}
+	db = db.First(apiKey)
+
+	return apiKey, query.done(db.Error)
+}
@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -13,6 +14,8 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/geometry-labs/icon-blocks/global"
+	"github.com/geometry-labs/icon-blocks/metrics"
 	"github.com/geometry-labs/icon-blocks/models"
 )
 
@@ -47,7 +50,12 @@ func GetBlockModel() *BlockModel {
 			zap.S().Fatal("BlockModel: Unable migrate postgres table: ", err.Error())
 		}
 
-		StartBlockLoader()
+		// Metrics
+		metrics.RegisterLoaderChannelDepth("blocks", func() int {
+			return len(blockModel.LoaderChannel)
+		})
+
+		StartBlockLoader(global.ShutdownContext())
 	})
 
 	return blockModel
@@ -61,24 +69,26 @@ func (m *BlockModel) Migrate() error {
 }
 
 // Insert - Insert block into table
-func (m *BlockModel) Insert(block *models.Block) error {
+func (m *BlockModel) Insert(ctx context.Context, block *models.Block) error {
 
 	err := backoff.Retry(func() error {
-		query := m.db.Create(block)
+		query := m.db.WithContext(ctx).Create(block)
 		if query.Error != nil && !strings.Contains(query.Error.Error(), "duplicate key value violates unique constraint") {
 			zap.S().Warn("POSTGRES Insert Error : ", query.Error.Error())
 			return query.Error
 		}
 
 		return nil
-	}, backoff.NewExponentialBackOff())
+	}, backoff.WithContext(backoff.NewExponentialBackOff(), ctx))
 
 	return err
 }
 
 // SelectMany - select from blocks table
 // Returns: models, error (if present)
+// NOTE returns ErrQueryRejected for large skips without a number or hash filter, or a narrow range
 func (m *BlockModel) SelectMany(
+	ctx context.Context,
 	limit int,
 	skip int,
 	number uint32,
@@ -88,7 +98,14 @@ func (m *BlockModel) SelectMany(
 	createdBy string,
 	sort string,
 ) (*[]models.BlockAPIList, error) {
-	db := m.db
+	query := startRead(ctx, "BlockModel.SelectMany")
+
+	// Cost guard
+	if reason := checkBlocksQueryCost(limit, skip, number, startNumber, endNumber, hash); reason != "" {
+		return &[]models.BlockAPIList{}, query.reject(reason)
+	}
+
+	db := query.session(m.db)
 
 	// Latest blocks first
 	if sort != "" {
@@ -133,14 +150,16 @@ func (m *BlockModel) SelectMany(
 	blocks := &[]models.BlockAPIList{}
 	db = db.Find(blocks)
 
-	return blocks, db.Error
+	return blocks, query.done(db.Error)
 }
 
 // SelectOne - select from blocks table
 func (m *BlockModel) SelectOne(
+	ctx context.Context,
 	number uint32,
 ) (*models.Block, error) {
-	db := m.db
+	query := startRead(ctx, "BlockModel.SelectOne")
+	db := query.session(m.db)
 
 	db = db.Order("number desc")
 
@@ -151,14 +170,31 @@ func (m *BlockModel) SelectOne(
 	block := &models.Block{}
 	db = db.First(block)
 
-	return block, db.Error
+	return block, query.done(db.Error)
+}
+
+// SelectOneByHash - select from blocks table by block hash
+func (m *BlockModel) SelectOneByHash(
+	ctx context.Context,
+	hash string,
+) (*models.Block, error) {
+	query := startRead(ctx, "BlockModel.SelectOneByHash")
+	db := query.session(m.db)
+
+	db = db.Where("hash = ?", hash)
+
+	block := &models.Block{}
+	db = db.First(block)
+
+	return block, query.done(db.Error)
 }
 
 // UpdateOne - select from blocks table
 func (m *BlockModel) UpdateOne(
+	ctx context.Context,
 	block *models.Block,
 ) error {
-	db := m.db
+	db := m.db.WithContext(ctx)
 
 	db = db.Order("number desc")
 
@@ -170,9 +206,11 @@ func (m *BlockModel) UpdateOne(
 }
 
 func (m *BlockModel) UpsertOne(
+	ctx context.Context,
 	block *models.Block,
 ) error {
-	db := m.db
+	ctx, upsert := startUpsert(ctx, "blocks")
+	db := m.db.WithContext(ctx)
 
 	// map[string]interface{}
 	updateOnConflictValues := extractFilledFieldsFromModel(
@@ -186,16 +224,21 @@ func (m *BlockModel) UpsertOne(
 		DoUpdates: clause.Assignments(updateOnConflictValues),
 	}).Create(block)
 
-	return db.Error
+	return upsert.done(db.Error)
 }
 
 // StartBlockLoader starts loader
-func StartBlockLoader() {
+func StartBlockLoader(ctx context.Context) {
 	go func() {
 
 		for {
 			// Read block
-			newBlock := <-GetBlockModel().LoaderChannel
+			var newBlock *models.Block
+			select {
+			case newBlock = <-GetBlockModel().LoaderChannel:
+			case <-ctx.Done():
+				return
+			}
 
 			/////////////////
 			// Enrichments //
@@ -210,9 +253,9 @@ func StartBlockLoader() {
 			////////////////////////
 			// Block Transactions //
 			////////////////////////
-			allBlockTransactions, err := GetBlockTransactionModel().SelectMany(newBlock.Number)
+			allBlockTransactions, err := GetBlockTransactionModel().SelectMany(ctx, newBlock.Number)
 			if err != nil {
-				zap.S().Fatal(err.Error())
+				loaderFatal(ctx, err.Error())
 			}
 
 			// transaction fee
@@ -240,9 +283,9 @@ func StartBlockLoader() {
 			/////////////////////////////////
 			// Block Internal Transactions //
 			/////////////////////////////////
-			allBlockInternalTransactions, err := GetBlockInternalTransactionModel().SelectMany(newBlock.Number)
+			allBlockInternalTransactions, err := GetBlockInternalTransactionModel().SelectMany(ctx, newBlock.Number)
 			if err != nil {
-				zap.S().Fatal(err.Error())
+				loaderFatal(ctx, err.Error())
 			}
 
 			// internal transaction amount
@@ -262,16 +305,16 @@ func StartBlockLoader() {
 			///////////////////////////////
 			// Block Failed Transactions //
 			///////////////////////////////
-			allBlockFailedTransactions, err := GetBlockFailedTransactionModel().SelectMany(newBlock.Number)
+			allBlockFailedTransactions, err := GetBlockFailedTransactionModel().SelectMany(ctx, newBlock.Number)
 			if err != nil {
-				zap.S().Fatal(err.Error())
+				loaderFatal(ctx, err.Error())
 			}
 			failedTransactionCount = len(*allBlockFailedTransactions)
 
 			////////////////
 			// Block Time //
 			////////////////
-			blockTimeRow, err := GetBlockTimeModel().SelectOne(newBlock.Number)
+			blockTimeRow, err := GetBlockTimeModel().SelectOne(ctx, newBlock.Number)
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				// No block_time entry yet
 				blockTime = 0
@@ -280,7 +323,7 @@ func StartBlockLoader() {
 				blockTime = blockTimeRow.Time
 			} else {
 				// Postgres error
-				zap.S().Fatal(err.Error())
+				loaderFatal(ctx, err.Error())
 			}
 
 			newBlock.TransactionFees = transactionFees
@@ -293,20 +336,22 @@ func StartBlockLoader() {
 			//////////////////////
 			// Load to postgres //
 			//////////////////////
-			err = GetBlockModel().UpsertOne(newBlock)
+			err = GetBlockModel().UpsertOne(ctx, newBlock)
 			zap.S().Debug("Loader=Block, Number=", newBlock.Number, " - Upserted")
 			if err != nil {
 				// Postgres error
-				zap.S().Fatal("Loader=Block, Number=", newBlock.Number, " - Error: ", err.Error())
+				loaderFatal(ctx, "Loader=Block, Number=", newBlock.Number, " - Error: ", err.Error())
 			}
+
+			global.DoneInFlight()
 		}
 	}()
 }
 
 // reloadBlock - Send block back to loader for updates
-func reloadBlock(number uint32) error {
+func reloadBlock(ctx context.Context, number uint32) error {
 
-	curBlock, err := GetBlockModel().SelectOne(number)
+	curBlock, err := GetBlockModel().SelectOne(ctx, number)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		// Create empty block
 		curBlock = &models.Block{}
@@ -315,6 +360,7 @@ func reloadBlock(number uint32) error {
 		// Postgres error
 		return err
 	}
+	global.AddInFlight()
 	GetBlockModel().LoaderChannel <- curBlock
 
 	return nil
@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"sync"
@@ -9,6 +10,8 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/geometry-labs/icon-blocks/global"
+	"github.com/geometry-labs/icon-blocks/metrics"
 	"github.com/geometry-labs/icon-blocks/models"
 	"github.com/geometry-labs/icon-blocks/redis"
 )
@@ -43,7 +46,12 @@ func GetBlockCountModel() *BlockCountModel {
 			zap.S().Fatal("BlockCountModel: Unable migrate postgres table: ", err.Error())
 		}
 
-		StartBlockCountLoader()
+		// Metrics
+		metrics.RegisterLoaderChannelDepth("block_counts", func() int {
+			return len(blockCountModel.LoaderChannel)
+		})
+
+		StartBlockCountLoader(global.ShutdownContext())
 	})
 
 	return blockCountModel
@@ -57,8 +65,9 @@ func (m *BlockCountModel) Migrate() error {
 }
 
 // Select - select from blockCounts table
-func (m *BlockCountModel) SelectOne(_type string) (*models.BlockCount, error) {
-	db := m.db
+func (m *BlockCountModel) SelectOne(ctx context.Context, _type string) (*models.BlockCount, error) {
+	query := startRead(ctx, "BlockCountModel.SelectOne")
+	db := query.session(m.db)
 
 	// Set table
 	db = db.Model(&models.BlockCount{})
@@ -69,12 +78,13 @@ func (m *BlockCountModel) SelectOne(_type string) (*models.BlockCount, error) {
 	blockCount := &models.BlockCount{}
 	db = db.First(blockCount)
 
-	return blockCount, db.Error
+	return blockCount, query.done(db.Error)
 }
 
 // Select - select from blockCounts table
-func (m *BlockCountModel) SelectCount(_type string) (uint64, error) {
-	db := m.db
+func (m *BlockCountModel) SelectCount(ctx context.Context, _type string) (uint64, error) {
+	query := startRead(ctx, "BlockCountModel.SelectCount")
+	db := query.session(m.db)
 
 	// Set table
 	db = db.Model(&models.BlockCount{})
@@ -91,13 +101,15 @@ func (m *BlockCountModel) SelectCount(_type string) (uint64, error) {
 		count = uint64(blockCount.Number)
 	}
 
-	return count, db.Error
+	return count, query.done(db.Error)
 }
 
 func (m *BlockCountModel) UpsertOne(
+	ctx context.Context,
 	blockCount *models.BlockCount,
 ) error {
-	db := m.db
+	ctx, upsert := startUpsert(ctx, "block_counts")
+	db := m.db.WithContext(ctx)
 
 	// map[string]interface{}
 	updateOnConflictValues := extractFilledFieldsFromModel(
@@ -111,17 +123,22 @@ func (m *BlockCountModel) UpsertOne(
 		DoUpdates: clause.Assignments(updateOnConflictValues),
 	}).Create(blockCount)
 
-	return db.Error
+	return upsert.done(db.Error)
 }
 
 // StartBlockCountLoader starts loader
-func StartBlockCountLoader() {
+func StartBlockCountLoader(ctx context.Context) {
 	go func() {
 		postgresLoaderChan := GetBlockCountModel().LoaderChannel
 
 		for {
 			// Read block
-			newBlockCount := <-postgresLoaderChan
+			var newBlockCount *models.BlockCount
+			select {
+			case newBlockCount = <-postgresLoaderChan:
+			case <-ctx.Done():
+				return
+			}
 
 			//////////////////////////
 			// Get count from redis //
@@ -130,7 +147,7 @@ func StartBlockCountLoader() {
 
 			count, err := redis.GetRedisClient().GetCount(countKey)
 			if err != nil {
-				zap.S().Fatal(
+				loaderFatal(ctx,
 					"Loader=Block,",
 					"Number=", newBlockCount.Number,
 					" Type=", newBlockCount.Type,
@@ -140,11 +157,11 @@ func StartBlockCountLoader() {
 			// No count set yet
 			// Get from database
 			if count == -1 {
-				curBlockCount, err := GetBlockCountModel().SelectOne(newBlockCount.Type)
+				curBlockCount, err := GetBlockCountModel().SelectOne(ctx, newBlockCount.Type)
 				if errors.Is(err, gorm.ErrRecordNotFound) {
 					count = 0
 				} else if err != nil {
-					zap.S().Fatal(
+					loaderFatal(ctx,
 						"Loader=Block,",
 						"Number=", newBlockCount.Number,
 						" Type=", newBlockCount.Type,
@@ -157,7 +174,7 @@ func StartBlockCountLoader() {
 				err = redis.GetRedisClient().SetCount(countKey, int64(count))
 				if err != nil {
 					// Redis error
-					zap.S().Fatal(
+					loaderFatal(ctx,
 						"Loader=Block,",
 						"Number=", newBlockCount.Number,
 						" Type=", newBlockCount.Type,
@@ -174,9 +191,10 @@ func StartBlockCountLoader() {
 				newBlockCountIndex := &models.BlockCountIndex{
 					Number: newBlockCount.Number,
 				}
-				err = GetBlockCountIndexModel().Insert(newBlockCountIndex)
+				err = GetBlockCountIndexModel().Insert(ctx, newBlockCountIndex)
 				if err != nil {
 					// Record already exists, continue
+					global.DoneInFlight()
 					continue
 				}
 			}
@@ -185,7 +203,7 @@ func StartBlockCountLoader() {
 			count, err = redis.GetRedisClient().IncCount(countKey)
 			if err != nil {
 				// Redis error
-				zap.S().Fatal(
+				loaderFatal(ctx,
 					"Loader=Block,",
 					"Number=", newBlockCount.Number,
 					" Type=", newBlockCount.Type,
@@ -193,7 +211,7 @@ func StartBlockCountLoader() {
 			}
 			newBlockCount.Count = uint64(count)
 
-			err = GetBlockCountModel().UpsertOne(newBlockCount)
+			err = GetBlockCountModel().UpsertOne(ctx, newBlockCount)
 			zap.S().Debug(
 				"Loader=Block,",
 				"Number=", newBlockCount.Number,
@@ -201,12 +219,14 @@ func StartBlockCountLoader() {
 				" - Upsert")
 			if err != nil {
 				// Postgres error
-				zap.S().Fatal(
+				loaderFatal(ctx,
 					"Loader=Block,",
 					"Number=", newBlockCount.Number,
 					" Type=", newBlockCount.Type,
 					" - Error: ", err.Error())
 			}
+
+			global.DoneInFlight()
 		}
 	}()
 }
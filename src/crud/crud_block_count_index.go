@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"sync"
 
 	"go.uber.org/zap"
@@ -52,8 +53,8 @@ func (m *BlockCountIndexModel) Migrate() error {
 
 // Count - count all entries in blocblockices table
 // NOTE this function will take a long time
-func (m *BlockCountIndexModel) Count() (int64, error) {
-	db := m.db
+func (m *BlockCountIndexModel) Count(ctx context.Context) (int64, error) {
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&models.BlockCountIndex{})
@@ -66,8 +67,8 @@ func (m *BlockCountIndexModel) Count() (int64, error) {
 }
 
 // Insert - Insert blockCountByIndex into table
-func (m *BlockCountIndexModel) Insert(blockCountIndex *models.BlockCountIndex) error {
-	db := m.db
+func (m *BlockCountIndexModel) Insert(ctx context.Context, blockCountIndex *models.BlockCountIndex) error {
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&models.BlockCountIndex{})
@@ -0,0 +1,139 @@
+package crud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/global"
+	"github.com/geometry-labs/icon-blocks/metrics"
+	"github.com/geometry-labs/icon-blocks/models"
+	"github.com/geometry-labs/icon-blocks/redis"
+)
+
+// BlockFailedTransactionWebsocketIndexModel - type for blockFailedTransactionWebsocketIndex table model
+type BlockFailedTransactionWebsocketIndexModel struct {
+	db            *gorm.DB
+	model         *models.BlockFailedTransactionWebsocketIndex
+	modelORM      *models.BlockFailedTransactionWebsocketIndexORM
+	LoaderChannel chan *models.BlockFailedTransaction // Write BlockFailedTransaction to create a BlockFailedTransactionWebsocketIndex
+}
+
+var blockFailedTransactionWebsocketIndexModel *BlockFailedTransactionWebsocketIndexModel
+var blockFailedTransactionWebsocketIndexModelOnce sync.Once
+
+// GetBlockFailedTransactionWebsocketIndexModel - create and/or return the blockFailedTransactionWebsocketIndexs table model
+func GetBlockFailedTransactionWebsocketIndexModel() *BlockFailedTransactionWebsocketIndexModel {
+	blockFailedTransactionWebsocketIndexModelOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		blockFailedTransactionWebsocketIndexModel = &BlockFailedTransactionWebsocketIndexModel{
+			db:            dbConn,
+			model:         &models.BlockFailedTransactionWebsocketIndex{},
+			modelORM:      &models.BlockFailedTransactionWebsocketIndexORM{},
+			LoaderChannel: make(chan *models.BlockFailedTransaction, 1),
+		}
+
+		err := blockFailedTransactionWebsocketIndexModel.Migrate()
+		if err != nil {
+			zap.S().Fatal("BlockFailedTransactionWebsocketIndexModel: Unable migrate postgres table: ", err.Error())
+		}
+
+		// Metrics
+		metrics.RegisterLoaderChannelDepth("block_failed_transaction_websocket_indices", func() int {
+			return len(blockFailedTransactionWebsocketIndexModel.LoaderChannel)
+		})
+
+		StartBlockFailedTransactionWebsocketIndexLoader(global.ShutdownContext())
+	})
+
+	return blockFailedTransactionWebsocketIndexModel
+}
+
+// Migrate - migrate blockFailedTransactionWebsocketIndexs table
+func (m *BlockFailedTransactionWebsocketIndexModel) Migrate() error {
+	// Only using BlockFailedTransactionWebsocketIndexORM (ORM version of the proto generated struct) to create the TABLE
+	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	return err
+}
+
+// Insert - Insert blockFailedTransactionWebsocketIndex into table
+func (m *BlockFailedTransactionWebsocketIndexModel) Insert(ctx context.Context, blockFailedTransactionWebsocketIndex *models.BlockFailedTransactionWebsocketIndex) error {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&models.BlockFailedTransactionWebsocketIndex{})
+
+	db = db.Create(blockFailedTransactionWebsocketIndex)
+
+	return db.Error
+}
+
+// SelectOne - select from blockFailedTransactionWebsocketIndexs table
+func (m *BlockFailedTransactionWebsocketIndexModel) SelectOne(
+	ctx context.Context,
+	transactionHash string,
+) (*models.BlockFailedTransactionWebsocketIndex, error) {
+	query := startRead(ctx, "BlockFailedTransactionWebsocketIndexModel.SelectOne")
+	db := query.session(m.db)
+
+	// Set table
+	db = db.Model(&models.BlockFailedTransactionWebsocketIndex{})
+
+	// Transaction hash
+	db = db.Where("transaction_hash = ?", transactionHash)
+
+	blockFailedTransactionWebsocketIndex := &models.BlockFailedTransactionWebsocketIndex{}
+	db = db.First(blockFailedTransactionWebsocketIndex)
+
+	return blockFailedTransactionWebsocketIndex, query.done(db.Error)
+}
+
+// StartBlockFailedTransactionWebsocketIndexLoader starts loader
+func StartBlockFailedTransactionWebsocketIndexLoader(ctx context.Context) {
+	go func() {
+
+		for {
+			// Read blockFailedTransaction
+			var newBlockFailedTransaction *models.BlockFailedTransaction
+			select {
+			case newBlockFailedTransaction = <-GetBlockFailedTransactionWebsocketIndexModel().LoaderChannel:
+			case <-ctx.Done():
+				return
+			}
+
+			// BlockFailedTransaction -> BlockFailedTransactionWebsocketIndex
+			newBlockFailedTransactionWebsocketIndex := &models.BlockFailedTransactionWebsocketIndex{
+				TransactionHash: newBlockFailedTransaction.TransactionHash,
+			}
+
+			// Insert
+			_, err := GetBlockFailedTransactionWebsocketIndexModel().SelectOne(ctx, newBlockFailedTransactionWebsocketIndex.TransactionHash)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+
+				// Insert
+				err = GetBlockFailedTransactionWebsocketIndexModel().Insert(ctx, newBlockFailedTransactionWebsocketIndex)
+				if err != nil {
+					zap.S().Warn("Loader=BlockFailedTransactionWebsocketIndex, TransactionHash=", newBlockFailedTransaction.TransactionHash, " - Error: ", err.Error())
+				}
+
+				// Publish to redis
+				newBlockFailedTransactionJSON, _ := json.Marshal(newBlockFailedTransaction)
+				redis.GetRedisClient().Publish(ctx, config.Config.RedisChannelBlockFailedTransactions, newBlockFailedTransactionJSON)
+			} else if err != nil {
+				// Postgres error
+				loaderFatal(ctx, "Loader=BlockFailedTransactionWebsocketIndex, TransactionHash=", newBlockFailedTransaction.TransactionHash, " - Error: ", err.Error())
+			}
+
+			global.DoneInFlight()
+		}
+	}()
+}
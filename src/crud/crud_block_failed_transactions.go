@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"reflect"
 	"sync"
 
@@ -8,6 +9,8 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/geometry-labs/icon-blocks/global"
+	"github.com/geometry-labs/icon-blocks/metrics"
 	"github.com/geometry-labs/icon-blocks/models"
 )
 
@@ -41,7 +44,12 @@ func GetBlockFailedTransactionModel() *BlockFailedTransactionModel {
 			zap.S().Fatal("BlockFailedTransactionModel: Unable migrate postgres table: ", err.Error())
 		}
 
-		StartBlockFailedTransactionLoader()
+		// Metrics
+		metrics.RegisterLoaderChannelDepth("block_failed_transactions", func() int {
+			return len(blockFailedTransactionModel.LoaderChannel)
+		})
+
+		StartBlockFailedTransactionLoader(global.ShutdownContext())
 	})
 
 	return blockFailedTransactionModel
@@ -55,8 +63,8 @@ func (m *BlockFailedTransactionModel) Migrate() error {
 }
 
 // Insert - Insert blockFailedTransaction into table
-func (m *BlockFailedTransactionModel) Insert(blockFailedTransaction *models.BlockFailedTransaction) error {
-	db := m.db
+func (m *BlockFailedTransactionModel) Insert(ctx context.Context, blockFailedTransaction *models.BlockFailedTransaction) error {
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&models.BlockFailedTransaction{})
@@ -67,8 +75,9 @@ func (m *BlockFailedTransactionModel) Insert(blockFailedTransaction *models.Bloc
 }
 
 // SelectOne - select from blockFailedTransactions table
-func (m *BlockFailedTransactionModel) SelectOne(transactionHash string) (*models.BlockFailedTransaction, error) {
-	db := m.db
+func (m *BlockFailedTransactionModel) SelectOne(ctx context.Context, transactionHash string) (*models.BlockFailedTransaction, error) {
+	query := startRead(ctx, "BlockFailedTransactionModel.SelectOne")
+	db := query.session(m.db)
 
 	// Set table
 	db = db.Model(&models.BlockFailedTransaction{})
@@ -79,12 +88,13 @@ func (m *BlockFailedTransactionModel) SelectOne(transactionHash string) (*models
 	blockFailedTransaction := &models.BlockFailedTransaction{}
 	db = db.First(blockFailedTransaction)
 
-	return blockFailedTransaction, db.Error
+	return blockFailedTransaction, query.done(db.Error)
 }
 
 // SelectMany - select many from blockFailedTransactions table by block number
-func (m *BlockFailedTransactionModel) SelectMany(number uint32) (*[]models.BlockFailedTransaction, error) {
-	db := m.db
+func (m *BlockFailedTransactionModel) SelectMany(ctx context.Context, number uint32) (*[]models.BlockFailedTransaction, error) {
+	query := startRead(ctx, "BlockFailedTransactionModel.SelectMany")
+	db := query.session(m.db)
 
 	// Set table
 	db = db.Model(&models.BlockFailedTransaction{})
@@ -95,12 +105,30 @@ func (m *BlockFailedTransactionModel) SelectMany(number uint32) (*[]models.Block
 	blockFailedTransactions := &[]models.BlockFailedTransaction{}
 	db = db.Find(blockFailedTransactions)
 
-	return blockFailedTransactions, db.Error
+	return blockFailedTransactions, query.done(db.Error)
+}
+
+// SelectManyByNumbers - select many from blockFailedTransactions table by a set of block numbers
+// Used to batch lookups for several blocks into one query
+func (m *BlockFailedTransactionModel) SelectManyByNumbers(ctx context.Context, numbers []uint32) (*[]models.BlockFailedTransaction, error) {
+	query := startRead(ctx, "BlockFailedTransactionModel.SelectManyByNumbers")
+	db := query.session(m.db)
+
+	// Set table
+	db = db.Model(&models.BlockFailedTransaction{})
+
+	// Numbers
+	db = db.Where("number IN ?", numbers)
+
+	blockFailedTransactions := &[]models.BlockFailedTransaction{}
+	db = db.Find(blockFailedTransactions)
+
+	return blockFailedTransactions, query.done(db.Error)
 }
 
 // UpdateOne - update in blockFailedTransactions table
-func (m *BlockFailedTransactionModel) UpdateOne(blockFailedTransaction *models.BlockFailedTransaction) error {
-	db := m.db
+func (m *BlockFailedTransactionModel) UpdateOne(ctx context.Context, blockFailedTransaction *models.BlockFailedTransaction) error {
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&models.BlockFailedTransaction{})
@@ -114,9 +142,11 @@ func (m *BlockFailedTransactionModel) UpdateOne(blockFailedTransaction *models.B
 }
 
 func (m *BlockFailedTransactionModel) UpsertOne(
+	ctx context.Context,
 	blockFailedTransaction *models.BlockFailedTransaction,
 ) error {
-	db := m.db
+	ctx, upsert := startUpsert(ctx, "block_failed_transactions")
+	db := m.db.WithContext(ctx)
 
 	// map[string]interface{}
 	updateOnConflictValues := extractFilledFieldsFromModel(
@@ -130,34 +160,41 @@ func (m *BlockFailedTransactionModel) UpsertOne(
 		DoUpdates: clause.Assignments(updateOnConflictValues),
 	}).Create(blockFailedTransaction)
 
-	return db.Error
+	return upsert.done(db.Error)
 }
 
 // StartBlockFailedTransactionLoader starts loader
-func StartBlockFailedTransactionLoader() {
+func StartBlockFailedTransactionLoader(ctx context.Context) {
 	go func() {
 
 		for {
 			// Read newBlockFailedTransaction
-			newBlockFailedTransaction := <-GetBlockFailedTransactionModel().LoaderChannel
+			var newBlockFailedTransaction *models.BlockFailedTransaction
+			select {
+			case newBlockFailedTransaction = <-GetBlockFailedTransactionModel().LoaderChannel:
+			case <-ctx.Done():
+				return
+			}
 
 			//////////////////////
 			// Load to postgres //
 			//////////////////////
-			err := GetBlockFailedTransactionModel().UpsertOne(newBlockFailedTransaction)
+			err := GetBlockFailedTransactionModel().UpsertOne(ctx, newBlockFailedTransaction)
 			zap.S().Debug("Loader=BlockFailedTransaction, TransactionHash=", newBlockFailedTransaction.TransactionHash, " - Upserted")
 			if err != nil {
 				// Error
-				zap.S().Fatal("Loader=BlockFailedTransaction, TransactionHash=", newBlockFailedTransaction.TransactionHash, " - Error: ", err.Error())
+				loaderFatal(ctx, "Loader=BlockFailedTransaction, TransactionHash=", newBlockFailedTransaction.TransactionHash, " - Error: ", err.Error())
 			}
 
 			///////////////////////
 			// Force enrichments //
 			///////////////////////
-			err = reloadBlock(newBlockFailedTransaction.Number)
+			err = reloadBlock(ctx, newBlockFailedTransaction.Number)
 			if err != nil {
-				zap.S().Fatal(err.Error())
+				loaderFatal(ctx, err.Error())
 			}
+
+			global.DoneInFlight()
 		}
 	}()
 }
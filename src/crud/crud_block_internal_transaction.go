@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"reflect"
 	"sync"
 
@@ -8,6 +9,8 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/geometry-labs/icon-blocks/global"
+	"github.com/geometry-labs/icon-blocks/metrics"
 	"github.com/geometry-labs/icon-blocks/models"
 )
 
@@ -41,7 +44,12 @@ func GetBlockInternalTransactionModel() *BlockInternalTransactionModel {
 			zap.S().Fatal("BlockInternalTransactionModel: Unable migrate postgres table: ", err.Error())
 		}
 
-		StartBlockInternalTransactionLoader()
+		// Metrics
+		metrics.RegisterLoaderChannelDepth("block_internal_transactions", func() int {
+			return len(blockInternalTransactionModel.LoaderChannel)
+		})
+
+		StartBlockInternalTransactionLoader(global.ShutdownContext())
 	})
 
 	return blockInternalTransactionModel
@@ -55,8 +63,8 @@ func (m *BlockInternalTransactionModel) Migrate() error {
 }
 
 // Insert - Insert blockInternalTransaction into table
-func (m *BlockInternalTransactionModel) Insert(blockInternalTransaction *models.BlockInternalTransaction) error {
-	db := m.db
+func (m *BlockInternalTransactionModel) Insert(ctx context.Context, blockInternalTransaction *models.BlockInternalTransaction) error {
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&models.BlockInternalTransaction{})
@@ -67,8 +75,9 @@ func (m *BlockInternalTransactionModel) Insert(blockInternalTransaction *models.
 }
 
 // SelectOne - select from blockInternalTransactions table
-func (m *BlockInternalTransactionModel) SelectOne(transactionHash string, logIndex uint32) (*models.BlockInternalTransaction, error) {
-	db := m.db
+func (m *BlockInternalTransactionModel) SelectOne(ctx context.Context, transactionHash string, logIndex uint32) (*models.BlockInternalTransaction, error) {
+	query := startRead(ctx, "BlockInternalTransactionModel.SelectOne")
+	db := query.session(m.db)
 
 	// Set table
 	db = db.Model(&models.BlockInternalTransaction{})
@@ -82,12 +91,13 @@ func (m *BlockInternalTransactionModel) SelectOne(transactionHash string, logInd
 	blockInternalTransaction := &models.BlockInternalTransaction{}
 	db = db.First(blockInternalTransaction)
 
-	return blockInternalTransaction, db.Error
+	return blockInternalTransaction, query.done(db.Error)
 }
 
 // SelectMany - select many from blockInternalTransaction table by block number
-func (m *BlockInternalTransactionModel) SelectMany(number uint32) (*[]models.BlockInternalTransaction, error) {
-	db := m.db
+func (m *BlockInternalTransactionModel) SelectMany(ctx context.Context, number uint32) (*[]models.BlockInternalTransaction, error) {
+	query := startRead(ctx, "BlockInternalTransactionModel.SelectMany")
+	db := query.session(m.db)
 
 	// Set table
 	db = db.Model(&models.BlockInternalTransaction{})
@@ -98,12 +108,30 @@ func (m *BlockInternalTransactionModel) SelectMany(number uint32) (*[]models.Blo
 	blockInternalTransactions := &[]models.BlockInternalTransaction{}
 	db = db.Find(blockInternalTransactions)
 
-	return blockInternalTransactions, db.Error
+	return blockInternalTransactions, query.done(db.Error)
+}
+
+// SelectManyByNumbers - select many from blockInternalTransaction table by a set of block numbers
+// Used to batch lookups for several blocks into one query
+func (m *BlockInternalTransactionModel) SelectManyByNumbers(ctx context.Context, numbers []uint32) (*[]models.BlockInternalTransaction, error) {
+	query := startRead(ctx, "BlockInternalTransactionModel.SelectManyByNumbers")
+	db := query.session(m.db)
+
+	// Set table
+	db = db.Model(&models.BlockInternalTransaction{})
+
+	// Numbers
+	db = db.Where("number IN ?", numbers)
+
+	blockInternalTransactions := &[]models.BlockInternalTransaction{}
+	db = db.Find(blockInternalTransactions)
+
+	return blockInternalTransactions, query.done(db.Error)
 }
 
 // UpdateOne - update in blockInternalTransactions table
-func (m *BlockInternalTransactionModel) UpdateOne(blockInternalTransaction *models.BlockInternalTransaction) error {
-	db := m.db
+func (m *BlockInternalTransactionModel) UpdateOne(ctx context.Context, blockInternalTransaction *models.BlockInternalTransaction) error {
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&models.BlockInternalTransaction{})
@@ -120,9 +148,11 @@ func (m *BlockInternalTransactionModel) UpdateOne(blockInternalTransaction *mode
 }
 
 func (m *BlockInternalTransactionModel) UpsertOne(
+	ctx context.Context,
 	blockInternalTransaction *models.BlockInternalTransaction,
 ) error {
-	db := m.db
+	ctx, upsert := startUpsert(ctx, "block_internal_transactions")
+	db := m.db.WithContext(ctx)
 
 	// map[string]interface{}
 	updateOnConflictValues := extractFilledFieldsFromModel(
@@ -136,35 +166,42 @@ func (m *BlockInternalTransactionModel) UpsertOne(
 		DoUpdates: clause.Assignments(updateOnConflictValues),
 	}).Create(blockInternalTransaction)
 
-	return db.Error
+	return upsert.done(db.Error)
 }
 
 // StartBlockInternalTransactionLoader starts loader
-func StartBlockInternalTransactionLoader() {
+func StartBlockInternalTransactionLoader(ctx context.Context) {
 	go func() {
 
 		for {
 			// Read newBlockInternalTransaction
-			newBlockInternalTransaction := <-GetBlockInternalTransactionModel().LoaderChannel
+			var newBlockInternalTransaction *models.BlockInternalTransaction
+			select {
+			case newBlockInternalTransaction = <-GetBlockInternalTransactionModel().LoaderChannel:
+			case <-ctx.Done():
+				return
+			}
 
 			//////////////////////
 			// Load to postgres //
 			//////////////////////
-			err := GetBlockInternalTransactionModel().UpsertOne(newBlockInternalTransaction)
+			err := GetBlockInternalTransactionModel().UpsertOne(ctx, newBlockInternalTransaction)
 			zap.S().Debug("Loader=BlockInternalTransaction, Number=", newBlockInternalTransaction.Number, " TransactionHash=", newBlockInternalTransaction.TransactionHash, " LogIndex=", newBlockInternalTransaction.LogIndex, " - Upserted")
 			if err != nil {
 				// Postgres error
-				zap.S().Fatal("Loader=BlockInternalTransaction, Number=", newBlockInternalTransaction.Number, " TransactionHash=", newBlockInternalTransaction.TransactionHash, " LogIndex=", newBlockInternalTransaction.LogIndex, " - Error: ", err.Error())
+				loaderFatal(ctx, "Loader=BlockInternalTransaction, Number=", newBlockInternalTransaction.Number, " TransactionHash=", newBlockInternalTransaction.TransactionHash, " LogIndex=", newBlockInternalTransaction.LogIndex, " - Error: ", err.Error())
 			}
 
 			///////////////////////
 			// Force enrichments //
 			///////////////////////
-			err = reloadBlock(newBlockInternalTransaction.Number)
+			err = reloadBlock(ctx, newBlockInternalTransaction.Number)
 			if err != nil {
 				// Postgress error
-				zap.S().Fatal(err.Error())
+				loaderFatal(ctx, err.Error())
 			}
+
+			global.DoneInFlight()
 		}
 	}()
 }
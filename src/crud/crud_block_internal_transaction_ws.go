@@ -0,0 +1,144 @@
+package crud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/global"
+	"github.com/geometry-labs/icon-blocks/metrics"
+	"github.com/geometry-labs/icon-blocks/models"
+	"github.com/geometry-labs/icon-blocks/redis"
+)
+
+// BlockInternalTransactionWebsocketIndexModel - type for blockInternalTransactionWebsocketIndex table model
+type BlockInternalTransactionWebsocketIndexModel struct {
+	db            *gorm.DB
+	model         *models.BlockInternalTransactionWebsocketIndex
+	modelORM      *models.BlockInternalTransactionWebsocketIndexORM
+	LoaderChannel chan *models.BlockInternalTransaction // Write BlockInternalTransaction to create a BlockInternalTransactionWebsocketIndex
+}
+
+var blockInternalTransactionWebsocketIndexModel *BlockInternalTransactionWebsocketIndexModel
+var blockInternalTransactionWebsocketIndexModelOnce sync.Once
+
+// GetBlockInternalTransactionWebsocketIndexModel - create and/or return the blockInternalTransactionWebsocketIndexs table model
+func GetBlockInternalTransactionWebsocketIndexModel() *BlockInternalTransactionWebsocketIndexModel {
+	blockInternalTransactionWebsocketIndexModelOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		blockInternalTransactionWebsocketIndexModel = &BlockInternalTransactionWebsocketIndexModel{
+			db:            dbConn,
+			model:         &models.BlockInternalTransactionWebsocketIndex{},
+			modelORM:      &models.BlockInternalTransactionWebsocketIndexORM{},
+			LoaderChannel: make(chan *models.BlockInternalTransaction, 1),
+		}
+
+		err := blockInternalTransactionWebsocketIndexModel.Migrate()
+		if err != nil {
+			zap.S().Fatal("BlockInternalTransactionWebsocketIndexModel: Unable migrate postgres table: ", err.Error())
+		}
+
+		// Metrics
+		metrics.RegisterLoaderChannelDepth("block_internal_transaction_websocket_indices", func() int {
+			return len(blockInternalTransactionWebsocketIndexModel.LoaderChannel)
+		})
+
+		StartBlockInternalTransactionWebsocketIndexLoader(global.ShutdownContext())
+	})
+
+	return blockInternalTransactionWebsocketIndexModel
+}
+
+// Migrate - migrate blockInternalTransactionWebsocketIndexs table
+func (m *BlockInternalTransactionWebsocketIndexModel) Migrate() error {
+	// Only using BlockInternalTransactionWebsocketIndexORM (ORM version of the proto generated struct) to create the TABLE
+	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	return err
+}
+
+// Insert - Insert blockInternalTransactionWebsocketIndex into table
+func (m *BlockInternalTransactionWebsocketIndexModel) Insert(ctx context.Context, blockInternalTransactionWebsocketIndex *models.BlockInternalTransactionWebsocketIndex) error {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&models.BlockInternalTransactionWebsocketIndex{})
+
+	db = db.Create(blockInternalTransactionWebsocketIndex)
+
+	return db.Error
+}
+
+// SelectOne - select from blockInternalTransactionWebsocketIndexs table
+func (m *BlockInternalTransactionWebsocketIndexModel) SelectOne(
+	ctx context.Context,
+	transactionHash string,
+	logIndex uint32,
+) (*models.BlockInternalTransactionWebsocketIndex, error) {
+	query := startRead(ctx, "BlockInternalTransactionWebsocketIndexModel.SelectOne")
+	db := query.session(m.db)
+
+	// Set table
+	db = db.Model(&models.BlockInternalTransactionWebsocketIndex{})
+
+	// Transaction hash
+	db = db.Where("transaction_hash = ?", transactionHash)
+
+	// Log index
+	db = db.Where("log_index = ?", logIndex)
+
+	blockInternalTransactionWebsocketIndex := &models.BlockInternalTransactionWebsocketIndex{}
+	db = db.First(blockInternalTransactionWebsocketIndex)
+
+	return blockInternalTransactionWebsocketIndex, query.done(db.Error)
+}
+
+// StartBlockInternalTransactionWebsocketIndexLoader starts loader
+func StartBlockInternalTransactionWebsocketIndexLoader(ctx context.Context) {
+	go func() {
+
+		for {
+			// Read blockInternalTransaction
+			var newBlockInternalTransaction *models.BlockInternalTransaction
+			select {
+			case newBlockInternalTransaction = <-GetBlockInternalTransactionWebsocketIndexModel().LoaderChannel:
+			case <-ctx.Done():
+				return
+			}
+
+			// BlockInternalTransaction -> BlockInternalTransactionWebsocketIndex
+			newBlockInternalTransactionWebsocketIndex := &models.BlockInternalTransactionWebsocketIndex{
+				TransactionHash: newBlockInternalTransaction.TransactionHash,
+				LogIndex:        newBlockInternalTransaction.LogIndex,
+			}
+
+			// Insert
+			_, err := GetBlockInternalTransactionWebsocketIndexModel().SelectOne(ctx, newBlockInternalTransactionWebsocketIndex.TransactionHash, newBlockInternalTransactionWebsocketIndex.LogIndex)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+
+				// Insert
+				err = GetBlockInternalTransactionWebsocketIndexModel().Insert(ctx, newBlockInternalTransactionWebsocketIndex)
+				if err != nil {
+					zap.S().Warn("Loader=BlockInternalTransactionWebsocketIndex, TransactionHash=", newBlockInternalTransaction.TransactionHash, " LogIndex=", newBlockInternalTransaction.LogIndex, " - Error: ", err.Error())
+				}
+
+				// Publish to redis
+				newBlockInternalTransactionJSON, _ := json.Marshal(newBlockInternalTransaction)
+				redis.GetRedisClient().Publish(ctx, config.Config.RedisChannelBlockInternalTransactions, newBlockInternalTransactionJSON)
+			} else if err != nil {
+				// Postgres error
+				loaderFatal(ctx, "Loader=BlockInternalTransactionWebsocketIndex, TransactionHash=", newBlockInternalTransaction.TransactionHash, " LogIndex=", newBlockInternalTransaction.LogIndex, " - Error: ", err.Error())
+			}
+
+			global.DoneInFlight()
+		}
+	}()
+}
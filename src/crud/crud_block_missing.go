@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"reflect"
 	"sync"
 
@@ -8,6 +9,8 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/geometry-labs/icon-blocks/global"
+	"github.com/geometry-labs/icon-blocks/metrics"
 	"github.com/geometry-labs/icon-blocks/models"
 )
 
@@ -41,7 +44,12 @@ func GetBlockMissingModel() *BlockMissingModel {
 			zap.S().Fatal("BlockMissingModel: Unable migrate postgres table: ", err.Error())
 		}
 
-		StartBlockMissingLoader()
+		// Metrics
+		metrics.RegisterLoaderChannelDepth("block_missings", func() int {
+			return len(blockMissingModel.LoaderChannel)
+		})
+
+		StartBlockMissingLoader(global.ShutdownContext())
 	})
 
 	return blockMissingModel
@@ -55,9 +63,11 @@ func (m *BlockMissingModel) Migrate() error {
 }
 
 func (m *BlockMissingModel) UpsertOne(
+	ctx context.Context,
 	blockMissing *models.BlockMissing,
 ) error {
-	db := m.db
+	ctx, upsert := startUpsert(ctx, "block_missings")
+	db := m.db.WithContext(ctx)
 
 	// map[string]interface{}
 	updateOnConflictValues := extractFilledFieldsFromModel(
@@ -71,25 +81,32 @@ func (m *BlockMissingModel) UpsertOne(
 		DoUpdates: clause.Assignments(updateOnConflictValues),
 	}).Create(blockMissing)
 
-	return db.Error
+	return upsert.done(db.Error)
 }
 
-func StartBlockMissingLoader() {
+func StartBlockMissingLoader(ctx context.Context) {
 	go func() {
 
 		for {
 			// Read block
-			newBlockMissing := <-GetBlockMissingModel().LoaderChannel
+			var newBlockMissing *models.BlockMissing
+			select {
+			case newBlockMissing = <-GetBlockMissingModel().LoaderChannel:
+			case <-ctx.Done():
+				return
+			}
 
 			//////////////////////
 			// Load to postgres //
 			//////////////////////
-			err := GetBlockMissingModel().UpsertOne(newBlockMissing)
+			err := GetBlockMissingModel().UpsertOne(ctx, newBlockMissing)
 			zap.S().Debug("Loader=BlockMissing, Number=", newBlockMissing.Number, " - Upserted")
 			if err != nil {
 				// Postgres error
-				zap.S().Fatal("Loader=BlockMissing, Number=", newBlockMissing.Number, " - Error: ", err.Error())
+				loaderFatal(ctx, "Loader=BlockMissing, Number=", newBlockMissing.Number, " - Error: ", err.Error())
 			}
+
+			global.DoneInFlight()
 		}
 	}()
 }
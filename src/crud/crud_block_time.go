@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"reflect"
 	"strings"
 	"sync"
@@ -10,6 +11,8 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/geometry-labs/icon-blocks/global"
+	"github.com/geometry-labs/icon-blocks/metrics"
 	"github.com/geometry-labs/icon-blocks/models"
 )
 
@@ -43,7 +46,12 @@ func GetBlockTimeModel() *BlockTimeModel {
 			zap.S().Fatal("BlockTimeModel: Unable migrate postgres table: ", err.Error())
 		}
 
-		StartBlockTimeLoader()
+		// Metrics
+		metrics.RegisterLoaderChannelDepth("block_times", func() int {
+			return len(blockTimeModel.LoaderChannel)
+		})
+
+		StartBlockTimeLoader(global.ShutdownContext())
 	})
 
 	return blockTimeModel
@@ -57,26 +65,28 @@ func (m *BlockTimeModel) Migrate() error {
 }
 
 // Insert - Insert blockTime into table
-func (m *BlockTimeModel) Insert(blockTime *models.BlockTime) error {
+func (m *BlockTimeModel) Insert(ctx context.Context, blockTime *models.BlockTime) error {
 
 	err := backoff.Retry(func() error {
-		query := m.db.Create(blockTime)
+		query := m.db.WithContext(ctx).Create(blockTime)
 		if query.Error != nil && !strings.Contains(query.Error.Error(), "duplicate key value violates unique constraint") {
 			zap.S().Warn("POSTGRES Insert Error : ", query.Error.Error())
 			return query.Error
 		}
 
 		return nil
-	}, backoff.NewExponentialBackOff())
+	}, backoff.WithContext(backoff.NewExponentialBackOff(), ctx))
 
 	return err
 }
 
 // SelectOne - select from blockTimes table
 func (m *BlockTimeModel) SelectOne(
+	ctx context.Context,
 	number uint32,
 ) (*models.BlockTime, error) {
-	db := m.db
+	query := startRead(ctx, "BlockTimeModel.SelectOne")
+	db := query.session(m.db)
 
 	db = db.Order("number desc")
 
@@ -85,14 +95,47 @@ func (m *BlockTimeModel) SelectOne(
 	blockTime := &models.BlockTime{}
 	db = db.First(blockTime)
 
-	return blockTime, db.Error
+	return blockTime, query.done(db.Error)
+}
+
+// SelectLatest - select the highest block number from blockTimes table
+func (m *BlockTimeModel) SelectLatest(
+	ctx context.Context,
+) (*models.BlockTime, error) {
+	query := startRead(ctx, "BlockTimeModel.SelectLatest")
+	db := query.session(m.db)
+
+	db = db.Order("number desc")
+
+	blockTime := &models.BlockTime{}
+	db = db.First(blockTime)
+
+	return blockTime, query.done(db.Error)
+}
+
+// SelectManyByNumbers - select from blockTimes table by a set of block numbers
+// Used to batch lookups for several blocks into one query
+func (m *BlockTimeModel) SelectManyByNumbers(
+	ctx context.Context,
+	numbers []uint32,
+) (*[]models.BlockTime, error) {
+	query := startRead(ctx, "BlockTimeModel.SelectManyByNumbers")
+	db := query.session(m.db)
+
+	db = db.Where("number IN ?", numbers)
+
+	blockTimes := &[]models.BlockTime{}
+	db = db.Find(blockTimes)
+
+	return blockTimes, query.done(db.Error)
 }
 
 // UpdateOne - select from blockTimes table
 func (m *BlockTimeModel) UpdateOne(
+	ctx context.Context,
 	blockTime *models.BlockTime,
 ) error {
-	db := m.db
+	db := m.db.WithContext(ctx)
 
 	db = db.Order("number desc")
 
@@ -104,9 +147,11 @@ func (m *BlockTimeModel) UpdateOne(
 }
 
 func (m *BlockTimeModel) UpsertOne(
+	ctx context.Context,
 	blockTime *models.BlockTime,
 ) error {
-	db := m.db
+	ctx, upsert := startUpsert(ctx, "block_times")
+	db := m.db.WithContext(ctx)
 
 	// map[string]interface{}
 	updateOnConflictValues := extractFilledFieldsFromModel(
@@ -120,35 +165,42 @@ func (m *BlockTimeModel) UpsertOne(
 		DoUpdates: clause.Assignments(updateOnConflictValues),
 	}).Create(blockTime)
 
-	return db.Error
+	return upsert.done(db.Error)
 }
 
 // StartBlockTimeLoader starts loader
-func StartBlockTimeLoader() {
+func StartBlockTimeLoader(ctx context.Context) {
 	go func() {
 
 		for {
 			// Read blockTime
-			newBlockTime := <-GetBlockTimeModel().LoaderChannel
+			var newBlockTime *models.BlockTime
+			select {
+			case newBlockTime = <-GetBlockTimeModel().LoaderChannel:
+			case <-ctx.Done():
+				return
+			}
 
 			//////////////////////
 			// Load to postgres //
 			//////////////////////
-			err := GetBlockTimeModel().UpsertOne(newBlockTime)
+			err := GetBlockTimeModel().UpsertOne(ctx, newBlockTime)
 			zap.S().Debug("Loader=BlockTime, Number=", newBlockTime.Number, " - Upserted")
 			if err != nil {
 				// Postgres error
-				zap.S().Fatal("Loader=BlockTime, Number=", newBlockTime.Number, " - Error: ", err.Error())
+				loaderFatal(ctx, "Loader=BlockTime, Number=", newBlockTime.Number, " - Error: ", err.Error())
 			}
 
 			///////////////////////
 			// Force enrichments //
 			///////////////////////
-			err = reloadBlock(newBlockTime.Number)
+			err = reloadBlock(ctx, newBlockTime.Number)
 			if err != nil {
 				// Postgress error
-				zap.S().Fatal(err.Error())
+				loaderFatal(ctx, err.Error())
 			}
+
+			global.DoneInFlight()
 		}
 	}()
 }
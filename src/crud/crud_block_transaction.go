@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"reflect"
 	"sync"
 
@@ -8,6 +9,8 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/geometry-labs/icon-blocks/global"
+	"github.com/geometry-labs/icon-blocks/metrics"
 	"github.com/geometry-labs/icon-blocks/models"
 )
 
@@ -41,7 +44,12 @@ func GetBlockTransactionModel() *BlockTransactionModel {
 			zap.S().Fatal("BlockTransactionModel: Unable migrate postgres table: ", err.Error())
 		}
 
-		StartBlockTransactionLoader()
+		// Metrics
+		metrics.RegisterLoaderChannelDepth("block_transactions", func() int {
+			return len(blockTransactionModel.LoaderChannel)
+		})
+
+		StartBlockTransactionLoader(global.ShutdownContext())
 	})
 
 	return blockTransactionModel
@@ -55,8 +63,8 @@ func (m *BlockTransactionModel) Migrate() error {
 }
 
 // Insert - Insert blockTransaction into table
-func (m *BlockTransactionModel) Insert(blockTransaction *models.BlockTransaction) error {
-	db := m.db
+func (m *BlockTransactionModel) Insert(ctx context.Context, blockTransaction *models.BlockTransaction) error {
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&models.BlockTransaction{})
@@ -67,8 +75,9 @@ func (m *BlockTransactionModel) Insert(blockTransaction *models.BlockTransaction
 }
 
 // SelectOne - select from blockTransactions table
-func (m *BlockTransactionModel) SelectOne(transactionHash string) (*models.BlockTransaction, error) {
-	db := m.db
+func (m *BlockTransactionModel) SelectOne(ctx context.Context, transactionHash string) (*models.BlockTransaction, error) {
+	query := startRead(ctx, "BlockTransactionModel.SelectOne")
+	db := query.session(m.db)
 
 	// Set table
 	db = db.Model(&models.BlockTransaction{})
@@ -79,12 +88,13 @@ func (m *BlockTransactionModel) SelectOne(transactionHash string) (*models.Block
 	blockTransaction := &models.BlockTransaction{}
 	db = db.First(blockTransaction)
 
-	return blockTransaction, db.Error
+	return blockTransaction, query.done(db.Error)
 }
 
 // SelectMany - select many from blockTransactions table by block number
-func (m *BlockTransactionModel) SelectMany(number uint32) (*[]models.BlockTransaction, error) {
-	db := m.db
+func (m *BlockTransactionModel) SelectMany(ctx context.Context, number uint32) (*[]models.BlockTransaction, error) {
+	query := startRead(ctx, "BlockTransactionModel.SelectMany")
+	db := query.session(m.db)
 
 	// Set table
 	db = db.Model(&models.BlockTransaction{})
@@ -95,12 +105,30 @@ func (m *BlockTransactionModel) SelectMany(number uint32) (*[]models.BlockTransa
 	blockTransactions := &[]models.BlockTransaction{}
 	db = db.Find(blockTransactions)
 
-	return blockTransactions, db.Error
+	return blockTransactions, query.done(db.Error)
+}
+
+// SelectManyByNumbers - select many from blockTransactions table by a set of block numbers
+// Used to batch lookups for several blocks into one query
+func (m *BlockTransactionModel) SelectManyByNumbers(ctx context.Context, numbers []uint32) (*[]models.BlockTransaction, error) {
+	query := startRead(ctx, "BlockTransactionModel.SelectManyByNumbers")
+	db := query.session(m.db)
+
+	// Set table
+	db = db.Model(&models.BlockTransaction{})
+
+	// Numbers
+	db = db.Where("number IN ?", numbers)
+
+	blockTransactions := &[]models.BlockTransaction{}
+	db = db.Find(blockTransactions)
+
+	return blockTransactions, query.done(db.Error)
 }
 
 // UpdateOne - update in blockTransactions table
-func (m *BlockTransactionModel) UpdateOne(blockTransaction *models.BlockTransaction) error {
-	db := m.db
+func (m *BlockTransactionModel) UpdateOne(ctx context.Context, blockTransaction *models.BlockTransaction) error {
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&models.BlockTransaction{})
@@ -114,9 +142,11 @@ func (m *BlockTransactionModel) UpdateOne(blockTransaction *models.BlockTransact
 }
 
 func (m *BlockTransactionModel) UpsertOne(
+	ctx context.Context,
 	blockTransaction *models.BlockTransaction,
 ) error {
-	db := m.db
+	ctx, upsert := startUpsert(ctx, "block_transactions")
+	db := m.db.WithContext(ctx)
 
 	// map[string]interface{}
 	updateOnConflictValues := extractFilledFieldsFromModel(
@@ -130,35 +160,42 @@ func (m *BlockTransactionModel) UpsertOne(
 		DoUpdates: clause.Assignments(updateOnConflictValues),
 	}).Create(blockTransaction)
 
-	return db.Error
+	return upsert.done(db.Error)
 }
 
 // StartBlockTransactionLoader starts loader
-func StartBlockTransactionLoader() {
+func StartBlockTransactionLoader(ctx context.Context) {
 	go func() {
 
 		for {
 			// Read newBlockTransaction
-			newBlockTransaction := <-GetBlockTransactionModel().LoaderChannel
+			var newBlockTransaction *models.BlockTransaction
+			select {
+			case newBlockTransaction = <-GetBlockTransactionModel().LoaderChannel:
+			case <-ctx.Done():
+				return
+			}
 
 			//////////////////////
 			// Load to postgres //
 			//////////////////////
-			err := GetBlockTransactionModel().UpsertOne(newBlockTransaction)
+			err := GetBlockTransactionModel().UpsertOne(ctx, newBlockTransaction)
 			zap.S().Debug("Loader=BlockTransaction, Number=", newBlockTransaction.Number, " TransactionHash=", newBlockTransaction.TransactionHash, " - Upserted")
 			if err != nil {
 				// Postgres error
-				zap.S().Fatal("Loader=BlockTransaction, Number=", newBlockTransaction.Number, " TransactionHash=", newBlockTransaction.TransactionHash, " - Error: ", err.Error())
+				loaderFatal(ctx, "Loader=BlockTransaction, Number=", newBlockTransaction.Number, " TransactionHash=", newBlockTransaction.TransactionHash, " - Error: ", err.Error())
 			}
 
 			///////////////////////
 			// Force enrichments //
 			///////////////////////
-			err = reloadBlock(newBlockTransaction.Number)
+			err = reloadBlock(ctx, newBlockTransaction.Number)
 			if err != nil {
 				// Postgress error
-				zap.S().Fatal(err.Error())
+				loaderFatal(ctx, err.Error())
 			}
+
+			global.DoneInFlight()
 		}
 	}()
 }
@@ -0,0 +1,139 @@
+package crud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/global"
+	"github.com/geometry-labs/icon-blocks/metrics"
+	"github.com/geometry-labs/icon-blocks/models"
+	"github.com/geometry-labs/icon-blocks/redis"
+)
+
+// BlockTransactionWebsocketIndexModel - type for blockTransactionWebsocketIndex table model
+type BlockTransactionWebsocketIndexModel struct {
+	db            *gorm.DB
+	model         *models.BlockTransactionWebsocketIndex
+	modelORM      *models.BlockTransactionWebsocketIndexORM
+	LoaderChannel chan *models.BlockTransaction // Write BlockTransaction to create a BlockTransactionWebsocketIndex
+}
+
+var blockTransactionWebsocketIndexModel *BlockTransactionWebsocketIndexModel
+var blockTransactionWebsocketIndexModelOnce sync.Once
+
+// GetBlockTransactionWebsocketIndexModel - create and/or return the blockTransactionWebsocketIndexs table model
+func GetBlockTransactionWebsocketIndexModel() *BlockTransactionWebsocketIndexModel {
+	blockTransactionWebsocketIndexModelOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		blockTransactionWebsocketIndexModel = &BlockTransactionWebsocketIndexModel{
+			db:            dbConn,
+			model:         &models.BlockTransactionWebsocketIndex{},
+			modelORM:      &models.BlockTransactionWebsocketIndexORM{},
+			LoaderChannel: make(chan *models.BlockTransaction, 1),
+		}
+
+		err := blockTransactionWebsocketIndexModel.Migrate()
+		if err != nil {
+			zap.S().Fatal("BlockTransactionWebsocketIndexModel: Unable migrate postgres table: ", err.Error())
+		}
+
+		// Metrics
+		metrics.RegisterLoaderChannelDepth("block_transaction_websocket_indices", func() int {
+			return len(blockTransactionWebsocketIndexModel.LoaderChannel)
+		})
+
+		StartBlockTransactionWebsocketIndexLoader(global.ShutdownContext())
+	})
+
+	return blockTransactionWebsocketIndexModel
+}
+
+// Migrate - migrate blockTransactionWebsocketIndexs table
+func (m *BlockTransactionWebsocketIndexModel) Migrate() error {
+	// Only using BlockTransactionWebsocketIndexORM (ORM version of the proto generated struct) to create the TABLE
+	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	return err
+}
+
+// Insert - Insert blockTransactionWebsocketIndex into table
+func (m *BlockTransactionWebsocketIndexModel) Insert(ctx context.Context, blockTransactionWebsocketIndex *models.BlockTransactionWebsocketIndex) error {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&models.BlockTransactionWebsocketIndex{})
+
+	db = db.Create(blockTransactionWebsocketIndex)
+
+	return db.Error
+}
+
+// SelectOne - select from blockTransactionWebsocketIndexs table
+func (m *BlockTransactionWebsocketIndexModel) SelectOne(
+	ctx context.Context,
+	transactionHash string,
+) (*models.BlockTransactionWebsocketIndex, error) {
+	query := startRead(ctx, "BlockTransactionWebsocketIndexModel.SelectOne")
+	db := query.session(m.db)
+
+	// Set table
+	db = db.Model(&models.BlockTransactionWebsocketIndex{})
+
+	// Transaction hash
+	db = db.Where("transaction_hash = ?", transactionHash)
+
+	blockTransactionWebsocketIndex := &models.BlockTransactionWebsocketIndex{}
+	db = db.First(blockTransactionWebsocketIndex)
+
+	return blockTransactionWebsocketIndex, query.done(db.Error)
+}
+
+// StartBlockTransactionWebsocketIndexLoader starts loader
+func StartBlockTransactionWebsocketIndexLoader(ctx context.Context) {
+	go func() {
+
+		for {
+			// Read blockTransaction
+			var newBlockTransaction *models.BlockTransaction
+			select {
+			case newBlockTransaction = <-GetBlockTransactionWebsocketIndexModel().LoaderChannel:
+			case <-ctx.Done():
+				return
+			}
+
+			// BlockTransaction -> BlockTransactionWebsocketIndex
+			newBlockTransactionWebsocketIndex := &models.BlockTransactionWebsocketIndex{
+				TransactionHash: newBlockTransaction.TransactionHash,
+			}
+
+			// Insert
+			_, err := GetBlockTransactionWebsocketIndexModel().SelectOne(ctx, newBlockTransactionWebsocketIndex.TransactionHash)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+
+				// Insert
+				err = GetBlockTransactionWebsocketIndexModel().Insert(ctx, newBlockTransactionWebsocketIndex)
+				if err != nil {
+					zap.S().Warn("Loader=BlockTransactionWebsocketIndex, TransactionHash=", newBlockTransaction.TransactionHash, " - Error: ", err.Error())
+				}
+
+				// Publish to redis
+				newBlockTransactionJSON, _ := json.Marshal(newBlockTransaction)
+				redis.GetRedisClient().Publish(ctx, config.Config.RedisChannelBlockTransactions, newBlockTransactionJSON)
+			} else if err != nil {
+				// Postgres error
+				loaderFatal(ctx, "Loader=BlockTransactionWebsocketIndex, TransactionHash=", newBlockTransaction.TransactionHash, " - Error: ", err.Error())
+			}
+
+			global.DoneInFlight()
+		}
+	}()
+}
@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"sync"
@@ -8,6 +9,9 @@ import (
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/global"
+	"github.com/geometry-labs/icon-blocks/metrics"
 	"github.com/geometry-labs/icon-blocks/models"
 	"github.com/geometry-labs/icon-blocks/redis"
 )
@@ -42,7 +46,12 @@ func GetBlockWebsocketIndexModel() *BlockWebsocketIndexModel {
 			zap.S().Fatal("BlockWebsocketIndexModel: Unable migrate postgres table: ", err.Error())
 		}
 
-		StartBlockWebsocketIndexLoader()
+		// Metrics
+		metrics.RegisterLoaderChannelDepth("block_websocket_indices", func() int {
+			return len(blockWebsocketIndexModel.LoaderChannel)
+		})
+
+		StartBlockWebsocketIndexLoader(global.ShutdownContext())
 	})
 
 	return blockWebsocketIndexModel
@@ -56,8 +65,8 @@ func (m *BlockWebsocketIndexModel) Migrate() error {
 }
 
 // Insert - Insert blockWebsocketIndex into table
-func (m *BlockWebsocketIndexModel) Insert(blockWebsocketIndex *models.BlockWebsocketIndex) error {
-	db := m.db
+func (m *BlockWebsocketIndexModel) Insert(ctx context.Context, blockWebsocketIndex *models.BlockWebsocketIndex) error {
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&models.BlockWebsocketIndex{})
@@ -68,9 +77,11 @@ func (m *BlockWebsocketIndexModel) Insert(blockWebsocketIndex *models.BlockWebso
 }
 
 func (m *BlockWebsocketIndexModel) SelectOne(
+	ctx context.Context,
 	number uint32,
 ) (*models.BlockWebsocketIndex, error) {
-	db := m.db
+	query := startRead(ctx, "BlockWebsocketIndexModel.SelectOne")
+	db := query.session(m.db)
 
 	// Set table
 	db = db.Model(&models.BlockWebsocketIndex{})
@@ -80,16 +91,21 @@ func (m *BlockWebsocketIndexModel) SelectOne(
 	blockWebsocketIndex := &models.BlockWebsocketIndex{}
 	db = db.First(blockWebsocketIndex)
 
-	return blockWebsocketIndex, db.Error
+	return blockWebsocketIndex, query.done(db.Error)
 }
 
 // StartBlockWebsocketIndexLoader starts loader
-func StartBlockWebsocketIndexLoader() {
+func StartBlockWebsocketIndexLoader(ctx context.Context) {
 	go func() {
 
 		for {
 			// Read block
-			newBlockWebsocket := <-GetBlockWebsocketIndexModel().LoaderChannel
+			var newBlockWebsocket *models.BlockWebsocket
+			select {
+			case newBlockWebsocket = <-GetBlockWebsocketIndexModel().LoaderChannel:
+			case <-ctx.Done():
+				return
+			}
 
 			// BlockWebsocket -> BlockWebsocketIndex
 			newBlockWebsocketIndex := &models.BlockWebsocketIndex{
@@ -97,22 +113,24 @@ func StartBlockWebsocketIndexLoader() {
 			}
 
 			// Insert
-			_, err := GetBlockWebsocketIndexModel().SelectOne(newBlockWebsocketIndex.Number)
+			_, err := GetBlockWebsocketIndexModel().SelectOne(ctx, newBlockWebsocketIndex.Number)
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 
 				// Insert
-				err = GetBlockWebsocketIndexModel().Insert(newBlockWebsocketIndex)
+				err = GetBlockWebsocketIndexModel().Insert(ctx, newBlockWebsocketIndex)
 				if err != nil {
 					zap.S().Warn("Loader=Block, Number=", newBlockWebsocket.Number, " - Error: ", err.Error())
 				}
 
 				// Publish to redis
 				newBlockWebsocketJSON, _ := json.Marshal(newBlockWebsocket)
-				redis.GetRedisClient().Publish(newBlockWebsocketJSON)
+				redis.GetRedisClient().Publish(ctx, config.Config.RedisChannel, newBlockWebsocketJSON)
 			} else if err != nil {
 				// Postgres error
-				zap.S().Fatal("Loader=Block, Number=", newBlockWebsocket.Number, " - Error: ", err.Error())
+				loaderFatal(ctx, "Loader=Block, Number=", newBlockWebsocket.Number, " - Error: ", err.Error())
 			}
+
+			global.DoneInFlight()
 		}
 	}()
 }
@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"sync"
 
 	"go.uber.org/zap"
@@ -53,10 +54,12 @@ func (m *KafkaJobModel) Migrate() error {
 
 // SelectMany - select from kafkaJobs table
 func (m *KafkaJobModel) SelectMany(
+	ctx context.Context,
 	jobID string,
 	workerGroup string,
 ) (*[]models.KafkaJob, error) {
-	db := m.db
+	query := startRead(ctx, "KafkaJobModel.SelectMany")
+	db := query.session(m.db)
 
 	// Job ID
 	db = db.Where("job_id = ?", jobID)
@@ -67,5 +70,26 @@ func (m *KafkaJobModel) SelectMany(
 	kafkaJob := &[]models.KafkaJob{}
 	db = db.Find(kafkaJob)
 
-	return kafkaJob, db.Error
+	return kafkaJob, query.done(db.Error)
+}
+
+// InsertJob - insert the partitions of a new job and their progress in one transaction
+func (m *KafkaJobModel) InsertJob(
+	ctx context.Context,
+	kafkaJobs []*models.KafkaJob,
+	kafkaJobProgress []*models.KafkaJobProgress,
+) error {
+	// Progress table
+	GetKafkaJobProgressModel()
+
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, kafkaJob := range kafkaJobs {
+			err := tx.Create(kafkaJob).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(&kafkaJobProgress).Error
+	})
 }
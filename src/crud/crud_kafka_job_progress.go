@@ -0,0 +1,134 @@
+package crud
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/geometry-labs/icon-blocks/models"
+)
+
+// KafkaJobProgressModel - type for kafkaJobProgress table model
+type KafkaJobProgressModel struct {
+	db       *gorm.DB
+	model    *models.KafkaJobProgress
+	modelORM *models.KafkaJobProgressORM
+}
+
+var kafkaJobProgressModel *KafkaJobProgressModel
+var kafkaJobProgressModelOnce sync.Once
+
+// GetKafkaJobProgressModel - create and/or return the kafkaJobProgress table model
+func GetKafkaJobProgressModel() *KafkaJobProgressModel {
+	kafkaJobProgressModelOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		kafkaJobProgressModel = &KafkaJobProgressModel{
+			db:       dbConn,
+			model:    &models.KafkaJobProgress{},
+			modelORM: &models.KafkaJobProgressORM{},
+		}
+
+		err := kafkaJobProgressModel.Migrate()
+		if err != nil {
+			zap.S().Fatal("KafkaJobProgressModel: Unable migrate postgres table: ", err.Error())
+		}
+	})
+
+	return kafkaJobProgressModel
+}
+
+// Migrate - migrate kafkaJobProgress table
+func (m *KafkaJobProgressModel) Migrate() error {
+	// Only using KafkaJobProgressORM (ORM version of the proto generated struct) to create the TABLE
+	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	return err
+}
+
+// SelectMany - select from kafkaJobProgress table
+// NOTE every job when jobID is empty
+func (m *KafkaJobProgressModel) SelectMany(
+	ctx context.Context,
+	jobID string,
+) ([]*models.KafkaJobProgress, error) {
+	query := startRead(ctx, "KafkaJobProgressModel.SelectMany")
+	db := query.session(m.db)
+
+	// Job ID
+	if jobID != "" {
+		db = db.Where("job_id = ?", jobID)
+	}
+
+	db = db.Order("job_id, worker_group, topic, partition")
+
+	kafkaJobProgress := []*models.KafkaJobProgress{}
+	db = db.Find(&kafkaJobProgress)
+
+	return kafkaJobProgress, query.done(db.Error)
+}
+
+// InsertMissing - insert progress rows, keeping the rows that already exist
+// NOTE jobs inserted into kafka_jobs by hand have no progress until a worker starts them
+func (m *KafkaJobProgressModel) InsertMissing(
+	ctx context.Context,
+	kafkaJobProgress []*models.KafkaJobProgress,
+) error {
+	if len(kafkaJobProgress) == 0 {
+		return nil
+	}
+
+	db := m.db.WithContext(ctx)
+
+	db = db.Clauses(clause.OnConflict{DoNothing: true}).Create(&kafkaJobProgress)
+
+	return db.Error
+}
+
+// UpdateOffset - set the consumed offset and status of a pending or running partition
+// Returns: false if the partition is no longer pending or running, e.g. the job was cancelled
+func (m *KafkaJobProgressModel) UpdateOffset(
+	ctx context.Context,
+	kafkaJobProgress *models.KafkaJobProgress,
+) (bool, error) {
+	db := m.db.WithContext(ctx)
+
+	db = db.Model(&models.KafkaJobProgress{}).
+		Where("job_id = ?", kafkaJobProgress.JobId).
+		Where("worker_group = ?", kafkaJobProgress.WorkerGroup).
+		Where("topic = ?", kafkaJobProgress.Topic).
+		Where("partition = ?", kafkaJobProgress.Partition).
+		Where("status IN ?", []string{models.KafkaJobStatusPending, models.KafkaJobStatusRunning}).
+		Updates(map[string]interface{}{
+			"consumed_offset": kafkaJobProgress.ConsumedOffset,
+			"status":          kafkaJobProgress.Status,
+			"updated_at":      time.Now().Unix(),
+		})
+
+	return db.RowsAffected > 0, db.Error
+}
+
+// Cancel - cancel the pending and running partitions of a job
+// Returns: number of partitions cancelled
+func (m *KafkaJobProgressModel) Cancel(
+	ctx context.Context,
+	jobID string,
+) (int64, error) {
+	db := m.db.WithContext(ctx)
+
+	db = db.Model(&models.KafkaJobProgress{}).
+		Where("job_id = ?", jobID).
+		Where("status IN ?", []string{models.KafkaJobStatusPending, models.KafkaJobStatusRunning}).
+		Updates(map[string]interface{}{
+			"status":     models.KafkaJobStatusCancelled,
+			"updated_at": time.Now().Unix(),
+		})
+
+	return db.RowsAffected, db.Error
+}
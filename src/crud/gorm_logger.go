@@ -0,0 +1,89 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/geometry-labs/icon-blocks/logging"
+)
+
+// gormLogger - gorm logger writing to zap
+// NOTE queries run with a request context are logged with its request ID
+type gormLogger struct {
+	level         logger.LogLevel
+	slowThreshold time.Duration
+}
+
+func newGormLogger(slowThreshold time.Duration) *gormLogger {
+	return &gormLogger{
+		level:         logger.Warn,
+		slowThreshold: slowThreshold,
+	}
+}
+
+// LogMode - copy of the logger at a log level
+func (l *gormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	newLogger := *l
+	newLogger.level = level
+	return &newLogger
+}
+
+// Info - log at info level
+func (l *gormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Info {
+		logging.FromContext(ctx).Infof(msg, data...)
+	}
+}
+
+// Warn - log at warn level
+func (l *gormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Warn {
+		logging.FromContext(ctx).Warnf(msg, data...)
+	}
+}
+
+// Error - log at error level
+func (l *gormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Error {
+		logging.FromContext(ctx).Errorf(msg, data...)
+	}
+}
+
+// Trace - log failed and slow queries
+// NOTE record not found errors are expected and not logged
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && l.level >= logger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		sql, rows := fc()
+		logging.FromContext(ctx).Errorw("Query failed",
+			"error", err.Error(),
+			"elapsed", elapsed,
+			"rows", rows,
+			"sql", sql,
+		)
+	case l.slowThreshold != 0 && elapsed > l.slowThreshold && l.level >= logger.Warn:
+		sql, rows := fc()
+		logging.FromContext(ctx).Warnw("Slow query",
+			"threshold", l.slowThreshold,
+			"elapsed", elapsed,
+			"rows", rows,
+			"sql", sql,
+		)
+	case l.level >= logger.Info:
+		sql, rows := fc()
+		logging.FromContext(ctx).Debugw("Query",
+			"elapsed", elapsed,
+			"rows", rows,
+			"sql", sql,
+		)
+	}
+}
@@ -0,0 +1,55 @@
+package crud
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-blocks/metrics"
+	"github.com/geometry-labs/icon-blocks/tracing"
+)
+
+// loaderFatal - exit on a loader error
+// NOTE once the lifecycle context is cancelled, errors come from cancelled queries and only stop the loader
+func loaderFatal(ctx context.Context, args ...interface{}) {
+	if ctx.Err() != nil {
+		zap.S().Info("Loader stopped: ", ctx.Err().Error())
+		runtime.Goexit()
+	}
+
+	zap.S().Fatal(args...)
+}
+
+// upsert - span and latency of a loader upsert
+type upsert struct {
+	table string
+	start time.Time
+	span  trace.Span
+}
+
+// startUpsert - start tracing and timing a loader upsert into table
+func startUpsert(ctx context.Context, table string) (context.Context, *upsert) {
+	ctx, span := tracing.Start(
+		ctx,
+		"loader.upsert "+table,
+		trace.WithAttributes(attribute.String("db.sql.table", table)),
+	)
+
+	return ctx, &upsert{
+		table: table,
+		start: time.Now(),
+		span:  span,
+	}
+}
+
+// done - end the span and record the latency
+// Returns err
+func (u *upsert) done(err error) error {
+	metrics.LoaderUpsertLatencyHistogram.WithLabelValues(u.table).Observe(time.Since(u.start).Seconds())
+
+	return tracing.End(u.span, err)
+}
@@ -1,9 +1,8 @@
 package crud
 
 import (
+	"context"
 	"fmt"
-	"log"
-	"os"
 	"sync"
 	"time"
 
@@ -11,7 +10,6 @@ import (
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 
 	"github.com/geometry-labs/icon-blocks/config"
 )
@@ -67,24 +65,31 @@ func retryGetPostgresSession(dsn string) (*gorm.DB, error) {
 func createSession(dsn string) (*gorm.DB, error) {
 
 	slowThreshold := (time.Duration(config.Config.GormLoggingThresholdMilli) * time.Millisecond)
-	newLogger := logger.New(
-		log.New(os.Stdout, "\r\n", log.LstdFlags), // io writer
-		logger.Config{
-			SlowThreshold:             slowThreshold, // Slow SQL threshold
-			LogLevel:                  logger.Warn,   // Log level
-			IgnoreRecordNotFoundError: true,          // Ignore ErrRecordNotFound error for logger
-			Colorful:                  true,
-		},
-	)
+	newLogger := newGormLogger(slowThreshold)
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: newLogger})
 	if err != nil {
 		zap.S().Info("err:", err)
 	}
 
+	// Span around every statement
+	if pluginErr := db.Use(gormTracing{}); pluginErr != nil {
+		zap.S().Warn("Unable to register gorm tracing: ", pluginErr.Error())
+	}
+
 	sqlDB, _ := db.DB()
 	sqlDB.SetMaxIdleConns(config.Config.DbMaxIdleConnections)
 	sqlDB.SetMaxOpenConns(config.Config.DbMaxOpenConnections)
 
 	return db, err
 }
+
+// Ping - check the postgres connection
+func Ping(ctx context.Context) error {
+	sqlDB, err := getPostgresConn().DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.PingContext(ctx)
+}
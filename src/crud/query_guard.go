@@ -0,0 +1,112 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/logging"
+	"github.com/geometry-labs/icon-blocks/metrics"
+)
+
+// ErrQueryRejected - query shape known to be too expensive to run
+// NOTE the message is returned to API clients as is
+var ErrQueryRejected = errors.New("skip is too large without a number or hash filter, or a narrow start_number and end_number range")
+
+// ErrQueryTimeout - query cancelled after the statement timeout
+// NOTE the message is returned to API clients as is
+var ErrQueryTimeout = errors.New("query timed out")
+
+// readQuery - statement timeout and reporting for one crud read
+type readQuery struct {
+	name   string
+	parent context.Context
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// startRead - start a read, the query is cancelled after DB_STATEMENT_TIMEOUT_MILLI
+// NOTE a timeout of 0 disables the statement timeout, config validation only allows it in tests
+func startRead(ctx context.Context, name string) *readQuery {
+	timeoutCtx, cancel := context.WithCancel(ctx)
+	if config.Config.DbStatementTimeoutMilli > 0 {
+		timeoutCtx, cancel = context.WithTimeout(ctx, time.Duration(config.Config.DbStatementTimeoutMilli)*time.Millisecond)
+	}
+
+	return &readQuery{
+		name:   name,
+		parent: ctx,
+		ctx:    timeoutCtx,
+		cancel: cancel,
+	}
+}
+
+// session - db bound to the read context
+func (q *readQuery) session(db *gorm.DB) *gorm.DB {
+	return db.WithContext(q.ctx)
+}
+
+// done - release the read context and report timeouts
+// Returns: ErrQueryTimeout if the statement timeout was hit, else err
+func (q *readQuery) done(err error) error {
+	defer q.cancel()
+
+	if err == nil {
+		return nil
+	}
+
+	// NOTE cancellation by the caller, e.g. a closed request, is not a timeout
+	if errors.Is(err, context.DeadlineExceeded) || (q.ctx.Err() == context.DeadlineExceeded && q.parent.Err() == nil) {
+		metrics.CrudQueryTimeoutsCounter.WithLabelValues(q.name).Inc()
+		logging.FromContext(q.parent).Warnw("Query timed out",
+			"query", q.name,
+			"timeout_ms", config.Config.DbStatementTimeoutMilli,
+			"error", err.Error(),
+		)
+
+		return ErrQueryTimeout
+	}
+
+	return err
+}
+
+// reject - report a query refused by the cost guard
+func (q *readQuery) reject(reason string) error {
+	defer q.cancel()
+
+	metrics.CrudQueriesRejectedCounter.WithLabelValues(q.name).Inc()
+	logging.FromContext(q.parent).Warnw("Query rejected",
+		"query", q.name,
+		"reason", reason,
+	)
+
+	return ErrQueryRejected
+}
+
+// checkBlocksQueryCost - reason a blocks query is too expensive, empty if it is not
+// Offsets force postgres to walk every skipped row,
+// so large skips need a number or hash filter, or a start and end range narrow enough to bound the scan
+// NOTE a one-sided range does not bound the scan, rows are still walked from the newest or oldest block
+func checkBlocksQueryCost(limit int, skip int, number uint32, startNumber uint32, endNumber uint32, hash string) string {
+	if skip <= config.Config.DbMaxSkipWithoutRange {
+		return ""
+	}
+
+	// At most one row
+	if number != 0 || hash != "" {
+		return ""
+	}
+
+	// Scan bounded by the range width
+	if startNumber != 0 && endNumber != 0 {
+		width := int64(endNumber) - int64(startNumber) + 1
+		if width <= int64(config.Config.DbMaxSkipWithoutRange)+int64(limit) {
+			return ""
+		}
+	}
+
+	return "skip beyond DB_MAX_SKIP_WITHOUT_RANGE without a number or hash filter, or a narrow start and end range"
+}
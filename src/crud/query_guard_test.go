@@ -0,0 +1,71 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-blocks/config"
+)
+
+func TestCheckBlocksQueryCost(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.DbMaxSkipWithoutRange = 1000
+
+	assert.Equal("", checkBlocksQueryCost(100, 1000, 0, 0, 0, ""))
+	assert.NotEqual("", checkBlocksQueryCost(100, 1001, 0, 0, 0, ""))
+
+	// Filters bound the scan
+	assert.Equal("", checkBlocksQueryCost(100, 1001, 10, 0, 0, ""))
+	assert.Equal("", checkBlocksQueryCost(100, 1001, 0, 0, 0, "0xab"))
+
+	// One-sided ranges do not
+	assert.NotEqual("", checkBlocksQueryCost(100, 1001, 0, 10, 0, ""))
+	assert.NotEqual("", checkBlocksQueryCost(100, 1001, 0, 0, 10, ""))
+
+	// Narrow range
+	assert.Equal("", checkBlocksQueryCost(100, 1001, 0, 10, 1109, ""))
+	assert.Equal("", checkBlocksQueryCost(100, 5000, 0, 10, 20, ""))
+
+	// Wide range
+	assert.NotEqual("", checkBlocksQueryCost(100, 1001, 0, 10, 1110, ""))
+	assert.NotEqual("", checkBlocksQueryCost(100, 1001, 0, 1, 40000000, ""))
+}
+
+func TestReadQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.DbStatementTimeoutMilli = 1
+
+	// Errors other than timeouts are passed through
+	query := startRead(context.Background(), "test")
+	assert.Equal(nil, query.done(nil))
+	query = startRead(context.Background(), "test")
+	assert.Equal(gorm.ErrRecordNotFound, query.done(gorm.ErrRecordNotFound))
+
+	// Statement timeout
+	query = startRead(context.Background(), "test")
+	<-query.ctx.Done()
+	assert.Equal(ErrQueryTimeout, query.done(errors.New("canceling statement")))
+
+	// Cancelled by the caller
+	ctx, cancel := context.WithCancel(context.Background())
+	query = startRead(ctx, "test")
+	cancel()
+	assert.Equal(context.Canceled, query.done(context.Canceled))
+
+	// Cost guard
+	query = startRead(context.Background(), "test")
+	assert.Equal(ErrQueryRejected, query.reject("too expensive"))
+
+	// No timeout
+	config.Config.DbStatementTimeoutMilli = 0
+	query = startRead(context.Background(), "test")
+	_, ok := query.ctx.Deadline()
+	assert.Equal(false, ok)
+	query.done(nil)
+}
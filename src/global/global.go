@@ -1,19 +1,55 @@
 package global
 
 import (
+	"context"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 )
 
 // Version - service version
 const Version = "v0.1.0"
 
-// WaitShutdownSig - wait for system shutdown signal
+var shutdownChan = make(chan struct{})
+var shutdownOnce sync.Once
+
+// WaitShutdownSig - wait for system shutdown signal, or a call to Shutdown
 func WaitShutdownSig() {
 	// Listen for close sig
 	// Register for interupt (Ctrl+C) and SIGTERM (docker)
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-	<-sigChan
+
+	select {
+	case <-sigChan:
+	case <-shutdownChan:
+	}
+}
+
+// Shutdown - shut down as if a signal was received
+// Used by work that finishes on its own, e.g. kafka jobs
+func Shutdown() {
+	shutdownOnce.Do(func() {
+		close(shutdownChan)
+	})
+}
+
+var shutdownContext context.Context
+var shutdownContextOnce sync.Once
+
+// ShutdownContext - context cancelled once a shutdown signal is received
+// Used as the lifecycle context of long running go routines
+func ShutdownContext() context.Context {
+	shutdownContextOnce.Do(func() {
+		var cancel context.CancelFunc
+		shutdownContext, cancel = context.WithCancel(context.Background())
+
+		go func() {
+			WaitShutdownSig()
+			cancel()
+		}()
+	})
+
+	return shutdownContext
 }
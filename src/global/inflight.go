@@ -0,0 +1,23 @@
+package global
+
+import "sync/atomic"
+
+var inFlight int64
+
+// AddInFlight - count a message sent to a transformer, or a row sent to a loader
+func AddInFlight() {
+	atomic.AddInt64(&inFlight, 1)
+}
+
+// DoneInFlight - a counted message or row is processed
+// NOTE a transformer calls it after sending the rows of a message to the loaders,
+// a loader after its upsert and the rows it sent back to other loaders
+func DoneInFlight() {
+	atomic.AddInt64(&inFlight, -1)
+}
+
+// InFlight - messages and rows not processed yet
+// Used by kafka jobs to wait until every consumed message is in the database
+func InFlight() int64 {
+	return atomic.LoadInt64(&inFlight)
+}
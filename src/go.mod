@@ -3,23 +3,36 @@ module github.com/geometry-labs/icon-blocks
 go 1.16
 
 require (
+	github.com/BurntSushi/toml v1.2.1
 	github.com/InVisionApp/go-health/v2 v2.1.2
 	github.com/Shopify/sarama v1.29.1
 	github.com/arsmn/fiber-swagger/v2 v2.15.0
-	github.com/cenkalti/backoff/v4 v4.1.1
+	github.com/cenkalti/backoff v2.2.1+incompatible
+	github.com/cenkalti/backoff/v4 v4.1.3
+	github.com/fasthttp/websocket v0.0.0-20200320073529-1554a54587ab
+	github.com/go-redis/redis v6.15.5+incompatible
 	github.com/go-redis/redis/v8 v8.11.3
 	github.com/gofiber/fiber/v2 v2.15.0
 	github.com/gofiber/websocket/v2 v2.0.7
+	github.com/graphql-go/graphql v0.8.1
 	github.com/infobloxopen/atlas-app-toolkit v0.25.1
 	github.com/infobloxopen/protoc-gen-gorm v0.20.1
 	github.com/jinzhu/gorm v1.9.16
 	github.com/kelseyhightower/envconfig v1.4.0
 	github.com/prometheus/client_golang v1.11.0
+	github.com/prometheus/client_model v0.2.0
 	github.com/sirupsen/logrus v1.8.1
-	github.com/stretchr/testify v1.7.0
+	github.com/stretchr/testify v1.7.1
+	github.com/xdg-go/scram v1.0.2
+	go.opentelemetry.io/otel v1.7.0
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc v1.7.0
+	go.opentelemetry.io/otel/sdk v1.7.0
+	go.opentelemetry.io/otel/trace v1.7.0
 	go.uber.org/zap v1.18.1
-	google.golang.org/genproto v0.0.0-20210719143636-1d5a45f8e492
-	google.golang.org/protobuf v1.27.1
+	google.golang.org/genproto v0.0.0-20211118181313-81c1377c94b1
+	google.golang.org/grpc v1.46.0
+	google.golang.org/protobuf v1.28.0
+	gopkg.in/yaml.v3 v3.0.1
 	gorm.io/driver/postgres v1.1.0
 	gorm.io/gorm v1.21.12
 )
@@ -0,0 +1,111 @@
+package kafka
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+)
+
+// probeTimeout - wait for the message at a probed offset
+const probeTimeout = 10 * time.Second
+
+// narrowToBlockRange - narrow every partition range to the messages of blocks startBlock to stopBlock
+// Messages of a partition are in block order, so each bound is a binary search over offsets
+// NOTE a stop block of 0 keeps the high water marks
+func narrowToBlockRange(
+	client sarama.Client,
+	partitionRanges map[string]map[int32]partitionRange,
+	startBlock uint64,
+	stopBlock uint64,
+) error {
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return err
+	}
+	defer consumer.Close()
+
+	for topic, partitions := range partitionRanges {
+		for partition, offsets := range partitions {
+			blockAt := func(offset int64) (uint64, error) {
+				return probeBlockNumber(consumer, topic, partition, offset)
+			}
+
+			// First message of the start block
+			start, err := searchOffsets(offsets.Start, offsets.End, func(blockNumber uint64) bool {
+				return blockNumber >= startBlock
+			}, blockAt)
+			if err != nil {
+				return fmt.Errorf("topic %s partition %d: %s", topic, partition, err.Error())
+			}
+
+			// First message after the stop block
+			end := offsets.End
+			if stopBlock != 0 {
+				end, err = searchOffsets(start, offsets.End, func(blockNumber uint64) bool {
+					return blockNumber > stopBlock
+				}, blockAt)
+				if err != nil {
+					return fmt.Errorf("topic %s partition %d: %s", topic, partition, err.Error())
+				}
+			}
+
+			zap.S().Info(
+				"TOPIC=", topic,
+				",PARTITION=", partition,
+				",START_OFFSET=", start,
+				",END_OFFSET=", end,
+				" - Resolved block range ", startBlock, " to ", stopBlock,
+			)
+			partitionRanges[topic][partition] = partitionRange{Start: start, End: end}
+		}
+	}
+
+	return nil
+}
+
+// searchOffsets - first offset in low to high whose block matches, high if none does
+// matches must be false then true over the offsets, e.g. blockNumber >= startBlock
+func searchOffsets(
+	low int64,
+	high int64,
+	matches func(blockNumber uint64) bool,
+	blockAt func(offset int64) (uint64, error),
+) (int64, error) {
+	for low < high {
+		middle := low + (high-low)/2
+
+		blockNumber, err := blockAt(middle)
+		if err != nil {
+			return 0, err
+		}
+
+		if matches(blockNumber) {
+			high = middle
+		} else {
+			low = middle + 1
+		}
+	}
+
+	return low, nil
+}
+
+// probeBlockNumber - block number of the first message at or after offset
+// NOTE compacted or deleted offsets resolve to the next message
+func probeBlockNumber(consumer sarama.Consumer, topic string, partition int32, offset int64) (uint64, error) {
+	partitionConsumer, err := consumer.ConsumePartition(topic, partition, offset)
+	if err != nil {
+		return 0, err
+	}
+	defer partitionConsumer.Close()
+
+	select {
+	case msg := <-partitionConsumer.Messages():
+		return messageBlockNumber(topic, msg.Value)
+	case consumerErr := <-partitionConsumer.Errors():
+		return 0, consumerErr.Err
+	case <-time.After(probeTimeout):
+		return 0, fmt.Errorf("no message at offset %d after %s", offset, probeTimeout)
+	}
+}
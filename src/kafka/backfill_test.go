@@ -0,0 +1,55 @@
+package kafka
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchOffsets(t *testing.T) {
+	assert := assert.New(t)
+
+	// Offset 10 onwards, several messages per block
+	blocks := []uint64{100, 100, 101, 103, 103, 103, 104, 107}
+	probes := 0
+	blockAt := func(offset int64) (uint64, error) {
+		probes++
+		return blocks[offset-10], nil
+	}
+	search := func(matches func(uint64) bool) int64 {
+		offset, err := searchOffsets(10, 18, matches, blockAt)
+		assert.Nil(err)
+		return offset
+	}
+
+	// First message of a block
+	assert.Equal(int64(10), search(func(b uint64) bool { return b >= 100 }))
+	assert.Equal(int64(12), search(func(b uint64) bool { return b >= 101 }))
+	assert.Equal(int64(13), search(func(b uint64) bool { return b >= 103 }))
+
+	// Missing block, next block
+	assert.Equal(int64(13), search(func(b uint64) bool { return b >= 102 }))
+
+	// First message after a block
+	assert.Equal(int64(16), search(func(b uint64) bool { return b > 103 }))
+
+	// No match
+	assert.Equal(int64(18), search(func(b uint64) bool { return b > 107 }))
+
+	// Binary search
+	probes = 0
+	search(func(b uint64) bool { return b >= 104 })
+	assert.LessOrEqual(probes, 4)
+
+	// Empty range
+	offset, err := searchOffsets(5, 5, nil, nil)
+	assert.Nil(err)
+	assert.Equal(int64(5), offset)
+
+	// Probe error
+	_, err = searchOffsets(0, 10, func(uint64) bool { return true }, func(int64) (uint64, error) {
+		return 0, errors.New("probe failed")
+	})
+	assert.NotNil(err)
+}
@@ -0,0 +1,120 @@
+package kafka
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"github.com/xdg-go/scram"
+
+	"github.com/geometry-labs/icon-blocks/config"
+)
+
+// brokers - broker addresses from KAFKA_BROKERS, or KAFKA_BROKER_URL if unset
+func brokers() []string {
+	if len(config.Config.KafkaBrokers) > 0 {
+		return config.Config.KafkaBrokers
+	}
+
+	return strings.Split(config.Config.KafkaBrokerURL, ",")
+}
+
+// newSaramaConfig - client config with the version, TLS and SASL settings
+// Shared by the consumers, producers and health checks
+func newSaramaConfig() (*sarama.Config, error) {
+	version, err := sarama.ParseKafkaVersion(config.Config.KafkaVersion)
+	if err != nil {
+		return nil, fmt.Errorf("KAFKA_VERSION: %s", err.Error())
+	}
+
+	saramaConfig := sarama.NewConfig()
+
+	// Version
+	saramaConfig.Version = version
+
+	// TLS
+	if config.Config.KafkaTLSEnabled == true {
+		tlsConfig, err := newTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		saramaConfig.Net.TLS.Enable = true
+		saramaConfig.Net.TLS.Config = tlsConfig
+	}
+
+	// SASL
+	switch config.Config.KafkaSASLMechanism {
+	case "none":
+	case sarama.SASLTypePlaintext:
+		saramaConfig.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+	case sarama.SASLTypeSCRAMSHA256:
+		saramaConfig.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA256
+		saramaConfig.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &scramClient{hashGenerator: scram.SHA256}
+		}
+	case sarama.SASLTypeSCRAMSHA512:
+		saramaConfig.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
+		saramaConfig.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &scramClient{hashGenerator: scramSHA512}
+		}
+	default:
+		return nil, fmt.Errorf("KAFKA_SASL_MECHANISM: unknown mechanism %q", config.Config.KafkaSASLMechanism)
+	}
+	if saramaConfig.Net.SASL.Mechanism != "" {
+		saramaConfig.Net.SASL.Enable = true
+		saramaConfig.Net.SASL.User = config.Config.KafkaSASLUsername
+		saramaConfig.Net.SASL.Password = config.Config.KafkaSASLPassword
+	}
+
+	err = saramaConfig.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return saramaConfig, nil
+}
+
+// newTLSConfig - TLS config with the CA and client certificate, if set
+func newTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.Config.KafkaTLSInsecureSkipVerify,
+	}
+
+	// CA
+	// NOTE system roots are used without a CA file
+	if config.Config.KafkaTLSCAFile != "" {
+		caPEM, err := ioutil.ReadFile(config.Config.KafkaTLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("KAFKA_TLS_CA_FILE: %s", err.Error())
+		}
+
+		rootCAs := x509.NewCertPool()
+		if rootCAs.AppendCertsFromPEM(caPEM) == false {
+			return nil, errors.New("KAFKA_TLS_CA_FILE: no PEM certificates found")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	// Client certificate
+	if config.Config.KafkaTLSCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.Config.KafkaTLSCertFile, config.Config.KafkaTLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("KAFKA_TLS_CERT_FILE: %s", err.Error())
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// ValidateConfig - build the client config once, failing on invalid settings
+// NOTE called at startup so a bad combination does not surface as a connection retry loop
+func ValidateConfig() error {
+	_, err := newSaramaConfig()
+	return err
+}
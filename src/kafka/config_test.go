@@ -0,0 +1,95 @@
+package kafka
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-blocks/config"
+)
+
+func TestBrokers(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.KafkaBrokers = nil
+	config.Config.KafkaBrokerURL = "kafka-1:9092,kafka-2:9092"
+	assert.Equal([]string{"kafka-1:9092", "kafka-2:9092"}, brokers())
+
+	// KAFKA_BROKERS takes precedence
+	config.Config.KafkaBrokers = []string{"kafka-3:9093"}
+	assert.Equal([]string{"kafka-3:9093"}, brokers())
+	config.Config.KafkaBrokers = nil
+}
+
+func TestNewSaramaConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	saved := config.Config
+	defer func() { config.Config = saved }()
+
+	config.Config.KafkaVersion = "2.1.1"
+	config.Config.KafkaSASLMechanism = "none"
+
+	saramaConfig, err := newSaramaConfig()
+	assert.Nil(err)
+	assert.Equal("2.1.1", saramaConfig.Version.String())
+	assert.False(saramaConfig.Net.SASL.Enable)
+	assert.False(saramaConfig.Net.TLS.Enable)
+
+	// Unknown version
+	config.Config.KafkaVersion = "two"
+	_, err = newSaramaConfig()
+	assert.NotNil(err)
+	config.Config.KafkaVersion = "2.1.1"
+
+	// SASL PLAIN
+	config.Config.KafkaSASLMechanism = "PLAIN"
+	config.Config.KafkaSASLUsername = "user"
+	config.Config.KafkaSASLPassword = "password"
+	saramaConfig, err = newSaramaConfig()
+	assert.Nil(err)
+	assert.True(saramaConfig.Net.SASL.Enable)
+	assert.Equal(sarama.SASLMechanism(sarama.SASLTypePlaintext), saramaConfig.Net.SASL.Mechanism)
+	assert.Equal("user", saramaConfig.Net.SASL.User)
+
+	// SASL SCRAM
+	config.Config.KafkaSASLMechanism = "SCRAM-SHA-512"
+	saramaConfig, err = newSaramaConfig()
+	assert.Nil(err)
+	assert.Equal(sarama.SASLMechanism(sarama.SASLTypeSCRAMSHA512), saramaConfig.Net.SASL.Mechanism)
+	assert.NotNil(saramaConfig.Net.SASL.SCRAMClientGeneratorFunc)
+
+	client := saramaConfig.Net.SASL.SCRAMClientGeneratorFunc()
+	assert.Nil(client.Begin("user", "password", ""))
+	first, err := client.Step("")
+	assert.Nil(err)
+	assert.Contains(first, "n=user")
+	assert.False(client.Done())
+
+	// SASL without a password is rejected by sarama
+	config.Config.KafkaSASLPassword = ""
+	_, err = newSaramaConfig()
+	assert.NotNil(err)
+	config.Config.KafkaSASLMechanism = "none"
+
+	// TLS
+	config.Config.KafkaTLSEnabled = true
+	saramaConfig, err = newSaramaConfig()
+	assert.Nil(err)
+	assert.True(saramaConfig.Net.TLS.Enable)
+
+	// TLS with a CA file holding no certificates
+	dir, err := ioutil.TempDir("", "kafka-tls")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	caFile := filepath.Join(dir, "ca.pem")
+	assert.Nil(ioutil.WriteFile(caFile, []byte("not a certificate"), 0600))
+	config.Config.KafkaTLSCAFile = caFile
+	_, err = newSaramaConfig()
+	assert.EqualError(err, "KAFKA_TLS_CA_FILE: no PEM certificates found")
+}
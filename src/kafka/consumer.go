@@ -2,30 +2,46 @@ package kafka
 
 import (
 	"context"
-	"errors"
-	"os"
 	"time"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
-	"gorm.io/gorm"
 
 	"github.com/geometry-labs/icon-blocks/config"
 	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/global"
 	"github.com/geometry-labs/icon-blocks/models"
 )
 
 type kafkaTopicConsumer struct {
-	brokerURL     string
+	brokers       []string
 	topicNames    []string
 	TopicChannels map[string]chan *sarama.ConsumerMessage
 }
 
 var KafkaTopicConsumer *kafkaTopicConsumer
 
+// jobPollInterval - wait between checks for a kafka job in the database
+const jobPollInterval = 5 * time.Second
+
+// jobProgressInterval - wait between saves of the consumed offset of a kafka job partition
+const jobProgressInterval = 5 * time.Second
+
+// jobReportInterval - wait between reports of the progress of a kafka job
+const jobReportInterval = 30 * time.Second
+
 // StartWorkerConsumers - start consumer goroutines for Worker config
 func StartWorkerConsumers() {
 
+	// Validate broker, TLS and SASL config
+	err := ValidateConfig()
+	if err != nil {
+		zap.S().Fatal("Kafka: invalid config: ", err.Error())
+	}
+	if config.Config.KafkaSASLMechanism == sarama.SASLTypePlaintext && config.Config.KafkaTLSEnabled == false {
+		zap.S().Warn("Kafka: SASL PLAIN without TLS sends the password in clear text")
+	}
+
 	// Init topic names
 	topicNames := []string{
 		config.Config.ConsumerTopicBlocks,
@@ -41,7 +57,7 @@ func StartWorkerConsumers() {
 
 	// Init consumer
 	KafkaTopicConsumer = &kafkaTopicConsumer{
-		brokerURL:     config.Config.KafkaBrokerURL,
+		brokers:       brokers(),
 		topicNames:    topicNames,
 		TopicChannels: topicChannels,
 	}
@@ -53,7 +69,7 @@ func StartWorkerConsumers() {
 	// Partition
 	if config.Config.ConsumerIsPartitionConsumer == true {
 		zap.S().Info(
-			"kafkaBroker=", config.Config.KafkaBrokerURL,
+			"kafkaBrokers=", KafkaTopicConsumer.brokers,
 			" ConsumerPartitionTopic=", config.Config.ConsumerPartitionTopic,
 			" ConsumerPartition=", config.Config.ConsumerPartition,
 			" ConsumerPartitionStartOffset=", config.Config.ConsumerPartitionStartOffset,
@@ -69,18 +85,18 @@ func StartWorkerConsumers() {
 	// Tail
 	if config.Config.ConsumerIsTail == true {
 		zap.S().Info(
-			"kafkaBroker=", config.Config.KafkaBrokerURL,
+			"kafkaBrokers=", KafkaTopicConsumer.brokers,
 			" consumerTopics=", topicNames,
-			" consumerGroup=", config.Config.ConsumerGroup+"-"+config.Config.ConsumerJobID,
+			" consumerGroup=", JobGroup(config.Config.ConsumerJobID),
 			" - Starting Consumers")
-		go KafkaTopicConsumer.consumeGroup(config.Config.ConsumerGroup + "-" + config.Config.ConsumerJobID)
+		go KafkaTopicConsumer.consumeGroup(JobGroup(config.Config.ConsumerJobID))
 		return
 	}
 
 	// Head
 	// Default
 	zap.S().Info(
-		"kafkaBroker=", config.Config.KafkaBrokerURL,
+		"kafkaBrokers=", KafkaTopicConsumer.brokers,
 		" consumerTopics=", topicNames,
 		" consumerGroup=", config.Config.ConsumerGroup+"-head",
 		" - Starting Consumers")
@@ -92,19 +108,15 @@ func StartWorkerConsumers() {
 // Group Consumer //
 ////////////////////
 func (k *kafkaTopicConsumer) consumeGroup(group string) {
-	version, err := sarama.ParseKafkaVersion("2.1.1")
-	if err != nil {
-		zap.S().Panic("CONSUME GROUP ERROR: parsing Kafka version: ", err.Error())
-	}
-
 	///////////////////////////
 	// Consumer Group Config //
 	///////////////////////////
 
-	saramaConfig := sarama.NewConfig()
-
-	// Version
-	saramaConfig.Version = version
+	// Version, TLS and SASL
+	saramaConfig, err := newSaramaConfig()
+	if err != nil {
+		zap.S().Panic("CONSUME GROUP ERROR: creating config: ", err.Error())
+	}
 
 	// Initial Offset
 	saramaConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
@@ -123,7 +135,7 @@ func (k *kafkaTopicConsumer) consumeGroup(group string) {
 
 	var consumerGroup sarama.ConsumerGroup
 	for {
-		consumerGroup, err = sarama.NewConsumerGroup([]string{k.brokerURL}, group, saramaConfig)
+		consumerGroup, err = sarama.NewConsumerGroup(k.brokers, group, saramaConfig)
 		if err != nil {
 			zap.S().Warn("Creating consumer group consumerGroup err: ", err.Error())
 			zap.S().Info("Retrying in 3 seconds...")
@@ -133,88 +145,210 @@ func (k *kafkaTopicConsumer) consumeGroup(group string) {
 		break
 	}
 
+	// Stopped by a shutdown signal, or once the kafka job is finished
+	ctx, cancel := context.WithCancel(global.ShutdownContext())
+	defer cancel()
+
 	// Get Kafka Jobs from database
+	// NOTE only applicable if ConsumerJobID is given
 	jobID := config.Config.ConsumerJobID
-	kafkaJobs := &[]models.KafkaJob{}
+	var jobPartitions []*models.KafkaJobProgress
+	var tracker *jobTracker
 	if jobID != "" {
-		for {
-			// Wait until jobs are present
+		jobPartitions, tracker, err = waitForKafkaJobs(ctx, jobID, group)
+		if ctx.Err() != nil {
+			// Shutdown while waiting
+			consumerGroup.Close()
+			return
+		} else if err != nil {
+			// Postgres error
+			zap.S().Fatal("JOBID=", jobID, ",GROUP=", group, " - Unable to start Kafka Job: ", err.Error())
+		}
 
-			kafkaJobs, err = crud.GetKafkaJobModel().SelectMany(
-				jobID,
-				group,
-			)
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				zap.S().Info(
-					"JobID=", jobID,
-					",ConsumerGroup=", group,
-					" - Waiting for Kafka Job in database...")
-				time.Sleep(1)
-				continue
-			} else if err != nil {
-				// Postgres error
-				zap.S().Fatal(err.Error())
+		go reportJobProgress(ctx, jobID)
+		go func() {
+			select {
+			case <-tracker.done:
+				zap.S().Info("JOBID=", jobID, ",GROUP=", group, " - Kafka Job finished, stopping consumer group...")
+				cancel()
+			case <-ctx.Done():
 			}
+		}()
+	}
+
+	// From example: /sarama/blob/master/examples/consumergroup/main.go
+	claimConsumer := &ClaimConsumer{
+		topicNames:    k.topicNames,
+		topicChans:    k.TopicChannels,
+		group:         group,
+		jobPartitions: jobPartitions,
+		tracker:       tracker,
+	}
 
+	for {
+		// `Consume` should be called inside an infinite loop, when a
+		// server-side rebalance happens, the consumer session will need to be
+		// recreated to get the new claims
+		err := consumerGroup.Consume(ctx, k.topicNames, claimConsumer)
+		if err != nil {
+			zap.S().Warn("CONSUME GROUP ERROR: from consumer: ", err.Error())
+		}
+		// check if context was cancelled, signaling that the consumer should stop
+		if ctx.Err() != nil {
+			zap.S().Warn("CONSUME GROUP WARN: from context: ", ctx.Err().Error())
 			break
 		}
 	}
 
-	// From example: /sarama/blob/master/examples/consumergroup/main.go
-	ctx, cancel := context.WithCancel(context.Background())
-	go func() {
-		claimConsumer := &ClaimConsumer{
-			topicNames: k.topicNames,
-			topicChans: k.TopicChannels,
-			group:      group,
-			kafkaJobs:  *kafkaJobs,
+	// Commit marked offsets and leave the group
+	err = consumerGroup.Close()
+	if err != nil {
+		zap.S().Warn("CONSUME GROUP ERROR: closing consumer group: ", err.Error())
+	}
+
+	// Kafka job finished, shut the worker down once the loaders wrote every consumed message
+	if tracker != nil && global.ShutdownContext().Err() == nil {
+		zap.S().Info("JOBID=", jobID, ",GROUP=", group, " - Waiting for loaders to drain...")
+		if waitForDrain(global.ShutdownContext()) {
+			zap.S().Info("JOBID=", jobID, ",GROUP=", group, " - Kafka Job done, shutting down")
+			global.Shutdown()
+		}
+	}
+}
+
+// waitForKafkaJobs - wait until the job partitions of group are in the database
+// Polls every jobPollInterval, jobs may be created after the worker starts
+// Returns: the job partitions with their progress and a tracker of the unfinished partitions
+func waitForKafkaJobs(ctx context.Context, jobID string, group string) ([]*models.KafkaJobProgress, *jobTracker, error) {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		kafkaJobs, err := crud.GetKafkaJobModel().SelectMany(ctx, jobID, group)
+		if err != nil {
+			// Postgres error
+			return nil, nil, err
 		}
 
-		for {
-			// `Consume` should be called inside an infinite loop, when a
-			// server-side rebalance happens, the consumer session will need to be
-			// recreated to get the new claims
-			err := consumerGroup.Consume(ctx, k.topicNames, claimConsumer)
+		if len(*kafkaJobs) > 0 {
+			// NOTE jobs inserted by hand have no progress rows yet
+			kafkaJobProgress := []*models.KafkaJobProgress{}
+			for i := range *kafkaJobs {
+				kafkaJob := &(*kafkaJobs)[i]
+				kafkaJobProgress = append(kafkaJobProgress, &models.KafkaJobProgress{
+					JobId:          kafkaJob.JobId,
+					WorkerGroup:    kafkaJob.WorkerGroup,
+					Topic:          kafkaJob.Topic,
+					Partition:      kafkaJob.Partition,
+					StopOffset:     kafkaJob.StopOffset,
+					ConsumedOffset: -1,
+					Status:         models.KafkaJobStatusPending,
+				})
+			}
+			err = crud.GetKafkaJobProgressModel().InsertMissing(ctx, kafkaJobProgress)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// Progress of this worker group
+			// NOTE the same job id may be run by several worker groups
+			allProgress, err := crud.GetKafkaJobProgressModel().SelectMany(ctx, jobID)
 			if err != nil {
-				zap.S().Warn("CONSUME GROUP ERROR: from consumer: ", err.Error())
+				return nil, nil, err
 			}
-			// check if context was cancelled, signaling that the consumer should stop
-			if ctx.Err() != nil {
-				zap.S().Warn("CONSUME GROUP WARN: from context: ", ctx.Err().Error())
-				return
+			groupProgress := []*models.KafkaJobProgress{}
+			for _, p := range allProgress {
+				if p.WorkerGroup == group {
+					groupProgress = append(groupProgress, p)
+				}
 			}
+
+			return groupProgress, newJobTracker(groupProgress), nil
 		}
-	}()
 
-	// Waiting, so that consumerGroup remains alive
-	ch := make(chan int, 1)
-	<-ch
-	cancel()
+		zap.S().Info(
+			"JobID=", jobID,
+			",ConsumerGroup=", group,
+			" - Waiting for Kafka Job in database...")
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+	}
 }
 
 type ClaimConsumer struct {
-	topicNames []string
-	topicChans map[string]chan *sarama.ConsumerMessage
-	group      string
-	kafkaJobs  []models.KafkaJob
+	topicNames    []string
+	topicChans    map[string]chan *sarama.ConsumerMessage
+	group         string
+	jobPartitions []*models.KafkaJobProgress
+	tracker       *jobTracker
 }
 
-func (c *ClaimConsumer) Setup(_ sarama.ConsumerGroupSession) error { return nil }
-func (*ClaimConsumer) Cleanup(_ sarama.ConsumerGroupSession) error { return nil }
+func (c *ClaimConsumer) Setup(sess sarama.ConsumerGroupSession) error {
+	currentGroupMember.set(c.group, sess.MemberID())
+
+	// Start job partitions at their start offset
+	// NOTE MarkOffset only moves forward, offsets committed by an earlier session are kept
+	for _, p := range c.jobPartitions {
+		if p.StartOffset > 0 {
+			sess.MarkOffset(p.Topic, int32(p.Partition), int64(p.StartOffset), "")
+		}
+	}
+
+	return nil
+}
+func (c *ClaimConsumer) Cleanup(_ sarama.ConsumerGroupSession) error {
+	currentGroupMember.set(c.group, "")
+	return nil
+}
 func (c *ClaimConsumer) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 
 	topicName := claim.Topic()
 	partition := uint64(claim.Partition())
 
-	// find kafka job
-	var kafkaJob *models.KafkaJob = nil
-	for i, k := range c.kafkaJobs {
-		if k.Partition == partition && k.Topic == topicName {
-			kafkaJob = &(c.kafkaJobs[i])
+	// find kafka job partition
+	// NOTE only applicable if ConsumerJobID is given
+	var jobPartition *models.KafkaJobProgress = nil
+	for _, p := range c.jobPartitions {
+		if p.Partition == partition && p.Topic == topicName {
+			jobPartition = p
 			break
 		}
 	}
 
+	// Finished partitions are paused
+	// NOTE sarama has no partition pause, so messages are read and dropped without being marked,
+	// returning early would block the other partitions fetched from the same broker
+	isFinished := false
+	if jobPartition != nil {
+		isFinished = c.tracker.isFinished(topicName, partition)
+
+		// Committed offset is already past the stop offset
+		// NOTE the initial offset is OffsetOldest before the first commit
+		if isFinished == false && claim.InitialOffset() >= 0 && uint64(claim.InitialOffset()) > jobPartition.StopOffset {
+			c.finishJobPartition(jobPartition, claim.InitialOffset()-1)
+			isFinished = true
+		}
+
+		if isFinished == true {
+			zap.S().Info(
+				"JOBID=", jobPartition.JobId,
+				",GROUP=", c.group,
+				",TOPIC=", topicName,
+				",PARTITION=", partition,
+				" - Kafka Job partition finished, pausing partition...",
+			)
+		}
+	}
+
+	consumedOffset := int64(-1)
+	if claim.InitialOffset() > 0 {
+		consumedOffset = claim.InitialOffset() - 1
+	}
+	progressSavedAt := time.Now()
 	for {
 		var topicMsg *sarama.ConsumerMessage
 		select {
@@ -226,61 +360,138 @@ func (c *ClaimConsumer) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sar
 
 			topicMsg = msg
 		case <-time.After(5 * time.Second):
+			if isFinished == true {
+				continue
+			}
 			zap.S().Info("GROUP=", c.group, ",TOPIC=", topicName, " - No new kafka messages, waited 5 secs...")
+
+			// NOTE check for cancellation while idle
+			if jobPartition != nil && c.saveJobProgress(jobPartition, consumedOffset, models.KafkaJobStatusRunning) == false {
+				isFinished = true
+			}
 			continue
 		case <-sess.Context().Done():
 			zap.S().Warn("GROUP=", c.group, ",TOPIC=", topicName, " - Session is done, exiting ConsumeClaim loop...")
 			return nil
 		}
 
+		// Paused
+		if isFinished == true {
+			continue
+		}
+
+		// Check if kafka job partition ended before this message
+		if jobPartition != nil && isPastStop(jobPartition, topicMsg) {
+			c.finishJobPartition(jobPartition, consumedOffset)
+			isFinished = true
+			continue
+		}
+
 		zap.S().Info("GROUP=", c.group, ",TOPIC=", topicName, ",PARTITION=", partition, ",OFFSET=", topicMsg.Offset, " - New message")
 		sess.MarkMessage(topicMsg, "")
 
+		// Lag
+		setConsumerLag(topicMsg, claim.HighWaterMarkOffset())
+
 		// Broadcast
+		span := startConsumeSpan(sess.Context(), topicMsg)
+		global.AddInFlight()
 		c.topicChans[topicName] <- topicMsg
+		span.End()
 
-		// Check if kafka job is done
-		// NOTE only applicable if ConsumerKafkaJobID is given
-		if kafkaJob != nil &&
-			uint64(topicMsg.Offset) >= kafkaJob.StopOffset+1000 {
-			// Job done
-			zap.S().Info(
-				"JOBID=", config.Config.ConsumerJobID,
-				"GROUP=", c.group,
-				",TOPIC=", topicName,
-				",PARTITION=", partition,
-				" - Kafka Job done...exiting",
-			)
-			os.Exit(0)
+		consumedOffset = topicMsg.Offset
+		if jobPartition == nil {
+			continue
+		}
+
+		// Check if kafka job partition ends with this message
+		if isAtStop(jobPartition, topicMsg) {
+			c.finishJobPartition(jobPartition, consumedOffset)
+			isFinished = true
+			continue
+		}
+
+		// Progress
+		if time.Since(progressSavedAt) >= jobProgressInterval {
+			progressSavedAt = time.Now()
+
+			if c.saveJobProgress(jobPartition, consumedOffset, models.KafkaJobStatusRunning) == false {
+				isFinished = true
+			}
 		}
 	}
-	return nil
+}
+
+// finishJobPartition - record a job partition as done at consumedOffset
+func (c *ClaimConsumer) finishJobPartition(jobPartition *models.KafkaJobProgress, consumedOffset int64) {
+	c.saveJobProgress(jobPartition, consumedOffset, models.KafkaJobStatusDone)
+	c.tracker.finish(jobPartition.Topic, jobPartition.Partition)
+
+	zap.S().Info(
+		"JOBID=", jobPartition.JobId,
+		",GROUP=", c.group,
+		",TOPIC=", jobPartition.Topic,
+		",PARTITION=", jobPartition.Partition,
+		",OFFSET=", consumedOffset,
+		" - Kafka Job partition done...",
+	)
+}
+
+// saveJobProgress - record the consumed offset of a job partition
+// Returns: false if the partition was cancelled, it is then finished
+// NOTE postgres errors are logged, progress is saved again on the next update
+func (c *ClaimConsumer) saveJobProgress(jobPartition *models.KafkaJobProgress, consumedOffset int64, status string) bool {
+	updated, err := crud.GetKafkaJobProgressModel().UpdateOffset(
+		global.ShutdownContext(),
+		&models.KafkaJobProgress{
+			JobId:          jobPartition.JobId,
+			WorkerGroup:    jobPartition.WorkerGroup,
+			Topic:          jobPartition.Topic,
+			Partition:      jobPartition.Partition,
+			ConsumedOffset: consumedOffset,
+			Status:         status,
+		},
+	)
+	if err != nil {
+		zap.S().Warn("JOBID=", jobPartition.JobId, ",GROUP=", c.group, " - Unable to save Kafka Job progress: ", err.Error())
+		return true
+	}
+
+	if updated == false {
+		zap.S().Info(
+			"JOBID=", jobPartition.JobId,
+			",GROUP=", c.group,
+			",TOPIC=", jobPartition.Topic,
+			",PARTITION=", jobPartition.Partition,
+			" - Kafka Job partition cancelled, pausing partition...",
+		)
+		c.tracker.finish(jobPartition.Topic, jobPartition.Partition)
+		return false
+	}
+
+	return true
 }
 
 ////////////////////////
 // Partition Consumer //
 ////////////////////////
 func (k *kafkaTopicConsumer) consumePartition(topic string, partition int, startOffset int) {
-	version, err := sarama.ParseKafkaVersion("2.1.1")
-	if err != nil {
-		zap.S().Panic("CONSUME GROUP ERROR: parsing Kafka version: ", err.Error())
-	}
-
 	///////////////////////////
 	// Consumer Group Config //
 	///////////////////////////
 
-	saramaConfig := sarama.NewConfig()
-
-	// Version
-	saramaConfig.Version = version
+	// Version, TLS and SASL
+	saramaConfig, err := newSaramaConfig()
+	if err != nil {
+		zap.S().Panic("CONSUME PARTITION ERROR: creating config: ", err.Error())
+	}
 
 	// Initial Offset
 	saramaConfig.Consumer.Offsets.Initial = sarama.OffsetNewest
 
 	var consumer sarama.Consumer
 	for {
-		consumer, err = sarama.NewConsumer([]string{k.brokerURL}, saramaConfig)
+		consumer, err = sarama.NewConsumer(k.brokers, saramaConfig)
 		if err != nil {
 			zap.S().Warn("Creating consumer err: ", err.Error())
 			zap.S().Info("Retrying in 3 seconds...")
@@ -323,8 +534,14 @@ func (k *kafkaTopicConsumer) consumePartition(topic string, partition int, start
 		}
 		zap.S().Debug("Consumer ", topic, ": Consumed message key=", string(topic_msg.Key))
 
+		// Lag
+		setConsumerLag(topic_msg, pc.HighWaterMarkOffset())
+
 		// Broadcast
+		span := startConsumeSpan(context.Background(), topic_msg)
+		global.AddInFlight()
 		k.TopicChannels[topic] <- topic_msg
+		span.End()
 
 		zap.S().Debug("Consumer ", topic, ": Broadcasted message key=", string(topic_msg.Key))
 	}
@@ -0,0 +1,108 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/geometry-labs/icon-blocks/config"
+)
+
+// groupMember - consumer group session held by this process
+type groupMember struct {
+	mutex    sync.RWMutex
+	group    string
+	memberID string
+}
+
+var currentGroupMember = &groupMember{}
+
+func (g *groupMember) set(group string, memberID string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.group = group
+	g.memberID = memberID
+}
+
+func (g *groupMember) get() (string, string) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	return g.group, g.memberID
+}
+
+func newHealthSaramaConfig() (*sarama.Config, error) {
+	saramaConfig, err := newSaramaConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE fail fast, checks are retried on the next poll
+	saramaConfig.Metadata.Retry.Max = 0
+
+	return saramaConfig, nil
+}
+
+// CheckBrokers - connect to the brokers and read the cluster metadata
+func CheckBrokers(ctx context.Context) error {
+	saramaConfig, err := newHealthSaramaConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := sarama.NewClient(brokers(), saramaConfig)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if len(client.Brokers()) == 0 {
+		return errors.New("no kafka brokers available")
+	}
+
+	return nil
+}
+
+// CheckGroupMembership - check this process is a member of its consumer group
+// NOTE only applicable to group consumers
+func CheckGroupMembership(ctx context.Context) error {
+	group, memberID := currentGroupMember.get()
+	if memberID == "" {
+		return errors.New("no consumer group session")
+	}
+
+	saramaConfig, err := newHealthSaramaConfig()
+	if err != nil {
+		return err
+	}
+
+	admin, err := sarama.NewClusterAdmin(brokers(), saramaConfig)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	descriptions, err := admin.DescribeConsumerGroups([]string{group})
+	if err != nil {
+		return err
+	}
+
+	for _, description := range descriptions {
+		if _, ok := description.Members[memberID]; ok {
+			return nil
+		}
+
+		return fmt.Errorf("member %s not in consumer group %s, group state %s", memberID, group, description.State)
+	}
+
+	return fmt.Errorf("consumer group %s not found", group)
+}
+
+// IsGroupConsumer - true unless the worker consumes a single partition
+func IsGroupConsumer() bool {
+	return config.Config.ConsumerIsPartitionConsumer == false
+}
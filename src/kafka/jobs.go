@@ -0,0 +1,485 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/global"
+	"github.com/geometry-labs/icon-blocks/metrics"
+	"github.com/geometry-labs/icon-blocks/models"
+)
+
+// ErrJobExists - a job with the same id was already created
+var ErrJobExists = errors.New("job already exists")
+
+// ErrJobNotFound - no job with the id
+var ErrJobNotFound = errors.New("job not found")
+
+// ErrInvalidJobID - job id is empty or unusable in a url
+var ErrInvalidJobID = errors.New("invalid job id")
+
+// Job - a kafka job and the progress of each of its partitions
+type Job struct {
+	JobID       string                     `json:"job_id"`
+	WorkerGroup string                     `json:"worker_group"`
+	Status      string                     `json:"status"`
+	Percent     float64                    `json:"percent"`
+	Partitions  []*models.KafkaJobProgress `json:"partitions"`
+}
+
+// JobOptions - what a new job consumes
+type JobOptions struct {
+	// Topics - topics to consume, the consumer topics when empty
+	Topics []string `json:"topics"`
+
+	// StartBlockNumber - first block to consume, 0 to consume from the oldest offsets
+	StartBlockNumber uint64 `json:"start_block_number"`
+
+	// StopBlockNumber - last block to consume, 0 to consume up to the high water marks
+	StopBlockNumber uint64 `json:"stop_block_number"`
+}
+
+// partitionRange - offsets of the messages of a job partition
+// End is the offset after the last message, a range with End <= Start is empty
+type partitionRange struct {
+	Start int64
+	End   int64
+}
+
+// JobGroup - consumer group of the workers running a job
+func JobGroup(jobID string) string {
+	return config.Config.ConsumerGroup + "-" + jobID
+}
+
+// CreateJob - create a job consuming topics up to their current high water marks
+// With block numbers, each partition is bounded to the messages of the block range
+func CreateJob(ctx context.Context, jobID string, options JobOptions) (*Job, error) {
+	if jobID == "" || strings.ContainsAny(jobID, "/ ") {
+		return nil, fmt.Errorf("%w %q", ErrInvalidJobID, jobID)
+	}
+	if options.StopBlockNumber != 0 && options.StartBlockNumber > options.StopBlockNumber {
+		return nil, fmt.Errorf("start block %d is after stop block %d", options.StartBlockNumber, options.StopBlockNumber)
+	}
+	if len(options.Topics) == 0 {
+		options.Topics = []string{
+			config.Config.ConsumerTopicBlocks,
+			config.Config.ConsumerTopicTransactions,
+			config.Config.ConsumerTopicLogs,
+		}
+	}
+
+	_, err := GetJob(ctx, jobID)
+	if err == nil {
+		return nil, ErrJobExists
+	} else if errors.Is(err, ErrJobNotFound) == false {
+		return nil, err
+	}
+
+	partitionRanges, err := getPartitionRanges(options)
+	if err != nil {
+		return nil, err
+	}
+
+	kafkaJobs, kafkaJobProgress := newJobPartitions(jobID, JobGroup(jobID), partitionRanges)
+	for _, p := range kafkaJobProgress {
+		p.StartBlockNumber = options.StartBlockNumber
+		p.StopBlockNumber = options.StopBlockNumber
+	}
+	err = crud.GetKafkaJobModel().InsertJob(ctx, kafkaJobs, kafkaJobProgress)
+	if err != nil {
+		return nil, err
+	}
+
+	return newJob(kafkaJobProgress), nil
+}
+
+// GetJob - job with its partition progress
+func GetJob(ctx context.Context, jobID string) (*Job, error) {
+	kafkaJobProgress, err := crud.GetKafkaJobProgressModel().SelectMany(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if len(kafkaJobProgress) == 0 {
+		return nil, ErrJobNotFound
+	}
+
+	return newJob(kafkaJobProgress), nil
+}
+
+// ListJobs - every job, ordered by job id
+func ListJobs(ctx context.Context) ([]*Job, error) {
+	kafkaJobProgress, err := crud.GetKafkaJobProgressModel().SelectMany(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return groupJobs(kafkaJobProgress), nil
+}
+
+// CancelJob - cancel the unfinished partitions of a job
+// NOTE workers running the job stop on their next progress update
+func CancelJob(ctx context.Context, jobID string) (*Job, error) {
+	_, err := crud.GetKafkaJobProgressModel().Cancel(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetJob(ctx, jobID)
+}
+
+// getPartitionRanges - offsets of every partition of the job topics
+// From the oldest offsets to the high water marks, narrowed to the block range if set
+func getPartitionRanges(options JobOptions) (map[string]map[int32]partitionRange, error) {
+	saramaConfig, err := newSaramaConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := sarama.NewClient(brokers(), saramaConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	partitionRanges := map[string]map[int32]partitionRange{}
+	for _, topic := range options.Topics {
+		partitions, err := client.Partitions(topic)
+		if err != nil {
+			return nil, fmt.Errorf("topic %s: %s", topic, err.Error())
+		}
+
+		partitionRanges[topic] = map[int32]partitionRange{}
+		for _, partition := range partitions {
+			oldestOffset, err := client.GetOffset(topic, partition, sarama.OffsetOldest)
+			if err != nil {
+				return nil, fmt.Errorf("topic %s partition %d: %s", topic, partition, err.Error())
+			}
+			highWaterMark, err := client.GetOffset(topic, partition, sarama.OffsetNewest)
+			if err != nil {
+				return nil, fmt.Errorf("topic %s partition %d: %s", topic, partition, err.Error())
+			}
+
+			partitionRanges[topic][partition] = partitionRange{Start: oldestOffset, End: highWaterMark}
+		}
+	}
+
+	if options.StartBlockNumber == 0 && options.StopBlockNumber == 0 {
+		return partitionRanges, nil
+	}
+
+	// Block range
+	err = narrowToBlockRange(client, partitionRanges, options.StartBlockNumber, options.StopBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return partitionRanges, nil
+}
+
+// newJobPartitions - job rows of the partition ranges
+// NOTE empty partitions have nothing to consume and are done from the start
+func newJobPartitions(
+	jobID string,
+	workerGroup string,
+	partitionRanges map[string]map[int32]partitionRange,
+) ([]*models.KafkaJob, []*models.KafkaJobProgress) {
+	kafkaJobs := []*models.KafkaJob{}
+	kafkaJobProgress := []*models.KafkaJobProgress{}
+
+	for topic, partitions := range partitionRanges {
+		for partition, offsets := range partitions {
+			startOffset := uint64(offsets.Start)
+			stopOffset := uint64(0)
+			status := models.KafkaJobStatusPending
+			if offsets.End > offsets.Start {
+				stopOffset = uint64(offsets.End - 1)
+			} else {
+				stopOffset = startOffset
+				status = models.KafkaJobStatusDone
+			}
+
+			kafkaJobs = append(kafkaJobs, &models.KafkaJob{
+				JobId:       jobID,
+				WorkerGroup: workerGroup,
+				Topic:       topic,
+				Partition:   uint64(partition),
+				StopOffset:  stopOffset,
+			})
+			kafkaJobProgress = append(kafkaJobProgress, &models.KafkaJobProgress{
+				JobId:          jobID,
+				WorkerGroup:    workerGroup,
+				Topic:          topic,
+				Partition:      uint64(partition),
+				StartOffset:    startOffset,
+				StopOffset:     stopOffset,
+				ConsumedOffset: -1,
+				Status:         status,
+			})
+		}
+	}
+
+	sort.Slice(kafkaJobProgress, func(i, j int) bool {
+		return partitionKey(kafkaJobProgress[i].Topic, kafkaJobProgress[i].Partition) <
+			partitionKey(kafkaJobProgress[j].Topic, kafkaJobProgress[j].Partition)
+	})
+
+	return kafkaJobs, kafkaJobProgress
+}
+
+// groupJobs - jobs of progress rows, ordered by job id
+func groupJobs(kafkaJobProgress []*models.KafkaJobProgress) []*Job {
+	jobIDs := []string{}
+	partitions := map[string][]*models.KafkaJobProgress{}
+	for _, p := range kafkaJobProgress {
+		if _, ok := partitions[p.JobId]; !ok {
+			jobIDs = append(jobIDs, p.JobId)
+		}
+		partitions[p.JobId] = append(partitions[p.JobId], p)
+	}
+	sort.Strings(jobIDs)
+
+	jobs := []*Job{}
+	for _, jobID := range jobIDs {
+		jobs = append(jobs, newJob(partitions[jobID]))
+	}
+
+	return jobs
+}
+
+// newJob - job of the progress rows of one job id
+func newJob(kafkaJobProgress []*models.KafkaJobProgress) *Job {
+	job := &Job{
+		Partitions: kafkaJobProgress,
+		Status:     jobStatus(kafkaJobProgress),
+		Percent:    jobPercent(kafkaJobProgress),
+	}
+	if len(kafkaJobProgress) > 0 {
+		job.JobID = kafkaJobProgress[0].JobId
+		job.WorkerGroup = kafkaJobProgress[0].WorkerGroup
+	}
+
+	return job
+}
+
+// jobStatus - status of a job from the status of its partitions
+// cancelled if any partition was cancelled, done once every partition is done,
+// running once any partition started, else pending
+func jobStatus(kafkaJobProgress []*models.KafkaJobProgress) string {
+	counts := map[string]int{}
+	for _, p := range kafkaJobProgress {
+		counts[p.Status]++
+	}
+
+	switch {
+	case counts[models.KafkaJobStatusCancelled] > 0:
+		return models.KafkaJobStatusCancelled
+	case counts[models.KafkaJobStatusDone] == len(kafkaJobProgress):
+		return models.KafkaJobStatusDone
+	case counts[models.KafkaJobStatusRunning] > 0 || counts[models.KafkaJobStatusDone] > 0:
+		return models.KafkaJobStatusRunning
+	}
+
+	return models.KafkaJobStatusPending
+}
+
+// jobPercent - percent of the messages of every partition consumed
+// NOTE done partitions count in full, they may have stopped early at the stop block
+func jobPercent(kafkaJobProgress []*models.KafkaJobProgress) float64 {
+	total := int64(0)
+	consumed := int64(0)
+	for _, p := range kafkaJobProgress {
+		if p.Status == models.KafkaJobStatusDone && p.StopOffset == p.StartOffset && p.ConsumedOffset < 0 {
+			// Empty partition
+			continue
+		}
+
+		partitionTotal := int64(p.StopOffset) - int64(p.StartOffset) + 1
+		partitionConsumed := p.ConsumedOffset - int64(p.StartOffset) + 1
+		if p.Status == models.KafkaJobStatusDone || partitionConsumed > partitionTotal {
+			partitionConsumed = partitionTotal
+		} else if partitionConsumed < 0 {
+			partitionConsumed = 0
+		}
+
+		total += partitionTotal
+		consumed += partitionConsumed
+	}
+
+	if total == 0 {
+		return 100
+	}
+
+	return math.Floor(float64(consumed)*10000/float64(total)) / 100
+}
+
+func partitionKey(topic string, partition uint64) string {
+	return fmt.Sprintf("%s/%010d", topic, partition)
+}
+
+////////////////////
+// Job Partitions //
+////////////////////
+
+// jobTracker - unfinished partitions of the job run by this worker
+// done is closed once every partition is done or cancelled
+type jobTracker struct {
+	mutex      sync.Mutex
+	unfinished map[string]bool
+	done       chan struct{}
+}
+
+func newJobTracker(kafkaJobProgress []*models.KafkaJobProgress) *jobTracker {
+	tracker := &jobTracker{
+		unfinished: map[string]bool{},
+		done:       make(chan struct{}),
+	}
+
+	for _, p := range kafkaJobProgress {
+		if p.Status == models.KafkaJobStatusPending || p.Status == models.KafkaJobStatusRunning {
+			tracker.unfinished[partitionKey(p.Topic, p.Partition)] = true
+		}
+	}
+	if len(tracker.unfinished) == 0 {
+		close(tracker.done)
+	}
+
+	return tracker
+}
+
+// isFinished - true once the partition is done or cancelled
+func (t *jobTracker) isFinished(topic string, partition uint64) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.unfinished[partitionKey(topic, partition)] == false
+}
+
+// finish - mark a partition done or cancelled
+func (t *jobTracker) finish(topic string, partition uint64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	key := partitionKey(topic, partition)
+	if t.unfinished[key] == false {
+		return
+	}
+
+	delete(t.unfinished, key)
+	if len(t.unfinished) == 0 {
+		close(t.done)
+	}
+}
+
+///////////////////
+// Stop Position //
+///////////////////
+
+// isPastStop - true if msg is after the last message of the job partition
+// NOTE messages of a partition are in block order, so the first message past the stop block ends the partition
+func isPastStop(jobPartition *models.KafkaJobProgress, msg *sarama.ConsumerMessage) bool {
+	if uint64(msg.Offset) > jobPartition.StopOffset {
+		return true
+	}
+
+	if jobPartition.StopBlockNumber == 0 {
+		return false
+	}
+
+	blockNumber, err := messageBlockNumber(msg.Topic, msg.Value)
+	if err != nil {
+		// NOTE unreadable messages are left to the transformers to report
+		return false
+	}
+
+	return blockNumber > jobPartition.StopBlockNumber
+}
+
+// isAtStop - true if msg is the last message of the job partition
+func isAtStop(jobPartition *models.KafkaJobProgress, msg *sarama.ConsumerMessage) bool {
+	return uint64(msg.Offset) == jobPartition.StopOffset
+}
+
+// messageBlockNumber - block number of a raw block, transaction or log topic value
+func messageBlockNumber(topic string, value []byte) (uint64, error) {
+	if len(value) < SchemaRegistryHeaderSize {
+		return 0, errors.New("message shorter than the schema registry header")
+	}
+	value = value[SchemaRegistryHeaderSize:]
+
+	switch topic {
+	case config.Config.ConsumerTopicBlocks:
+		block := &models.BlockRaw{}
+		err := proto.Unmarshal(value, block)
+		return uint64(block.Number), err
+	case config.Config.ConsumerTopicTransactions:
+		transaction := &models.TransactionRaw{}
+		err := proto.Unmarshal(value, transaction)
+		return transaction.BlockNumber, err
+	case config.Config.ConsumerTopicLogs:
+		log := &models.LogRaw{}
+		err := proto.Unmarshal(value, log)
+		return log.BlockNumber, err
+	}
+
+	return 0, fmt.Errorf("no block number in topic %s", topic)
+}
+
+///////////
+// Drain //
+///////////
+
+// drainPollInterval - wait between checks of the in-flight messages
+const drainPollInterval = 500 * time.Millisecond
+
+// waitForDrain - wait until every consumed message is loaded into the database
+// NOTE messages are counted from the consumer until the loaders upserted every row built from them
+// Returns: false if ctx was cancelled first
+func waitForDrain(ctx context.Context) bool {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for global.InFlight() > 0 {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	return true
+}
+
+// reportJobProgress - log and export the percent of the job consumed until ctx is done
+func reportJobProgress(ctx context.Context, jobID string) {
+	ticker := time.NewTicker(jobReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		job, err := GetJob(ctx, jobID)
+		if err != nil {
+			zap.S().Warn("JOBID=", jobID, " - Unable to read Kafka Job progress: ", err.Error())
+			continue
+		}
+
+		metrics.KafkaJobProgressGauge.WithLabelValues(jobID).Set(job.Percent)
+		zap.S().Info("JOBID=", jobID, ",STATUS=", job.Status, " - Kafka Job ", job.Percent, "% done")
+	}
+}
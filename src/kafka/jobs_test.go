@@ -0,0 +1,239 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/global"
+	"github.com/geometry-labs/icon-blocks/models"
+)
+
+func TestNewJobPartitions(t *testing.T) {
+	assert := assert.New(t)
+
+	kafkaJobs, kafkaJobProgress := newJobPartitions("job-1", "group-job-1", map[string]map[int32]partitionRange{
+		"blocks": {1: {Start: 0, End: 100}, 0: {Start: 0, End: 0}},
+		"logs":   {0: {Start: 3, End: 7}},
+	})
+	assert.Len(kafkaJobs, 3)
+	assert.Len(kafkaJobProgress, 3)
+
+	// Ordered by topic and partition
+	assert.Equal("blocks", kafkaJobProgress[0].Topic)
+	assert.Equal(uint64(0), kafkaJobProgress[0].Partition)
+	assert.Equal("blocks", kafkaJobProgress[1].Topic)
+	assert.Equal(uint64(1), kafkaJobProgress[1].Partition)
+	assert.Equal("logs", kafkaJobProgress[2].Topic)
+
+	// Empty partition is done
+	assert.Equal(models.KafkaJobStatusDone, kafkaJobProgress[0].Status)
+
+	// Stop at the last offset
+	assert.Equal(uint64(99), kafkaJobProgress[1].StopOffset)
+	assert.Equal(models.KafkaJobStatusPending, kafkaJobProgress[1].Status)
+	assert.Equal(int64(-1), kafkaJobProgress[1].ConsumedOffset)
+	assert.Equal(uint64(3), kafkaJobProgress[2].StartOffset)
+	assert.Equal(uint64(6), kafkaJobProgress[2].StopOffset)
+
+	for _, kafkaJob := range kafkaJobs {
+		assert.Equal("job-1", kafkaJob.JobId)
+		assert.Equal("group-job-1", kafkaJob.WorkerGroup)
+	}
+}
+
+func TestJobStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	progress := func(statuses ...string) []*models.KafkaJobProgress {
+		kafkaJobProgress := []*models.KafkaJobProgress{}
+		for _, status := range statuses {
+			kafkaJobProgress = append(kafkaJobProgress, &models.KafkaJobProgress{Status: status})
+		}
+		return kafkaJobProgress
+	}
+
+	assert.Equal(models.KafkaJobStatusPending, jobStatus(progress("pending", "pending")))
+	assert.Equal(models.KafkaJobStatusRunning, jobStatus(progress("pending", "running")))
+	assert.Equal(models.KafkaJobStatusRunning, jobStatus(progress("pending", "done")))
+	assert.Equal(models.KafkaJobStatusDone, jobStatus(progress("done", "done")))
+	assert.Equal(models.KafkaJobStatusCancelled, jobStatus(progress("done", "cancelled", "running")))
+}
+
+func TestJobPercent(t *testing.T) {
+	assert := assert.New(t)
+
+	// Nothing consumed
+	assert.Equal(float64(0), jobPercent([]*models.KafkaJobProgress{
+		{StartOffset: 10, StopOffset: 19, ConsumedOffset: -1, Status: "pending"},
+	}))
+
+	// Half of one partition, all of a done partition, empty partitions are ignored
+	assert.Equal(float64(75), jobPercent([]*models.KafkaJobProgress{
+		{StartOffset: 10, StopOffset: 19, ConsumedOffset: 14, Status: "running"},
+		{StartOffset: 0, StopOffset: 9, ConsumedOffset: 5, Status: "done"},
+		{StartOffset: 0, StopOffset: 0, ConsumedOffset: -1, Status: "done"},
+	}))
+
+	// Rounded down
+	assert.Equal(float64(33.33), jobPercent([]*models.KafkaJobProgress{
+		{StartOffset: 0, StopOffset: 2, ConsumedOffset: 0, Status: "running"},
+	}))
+
+	// Only empty partitions
+	assert.Equal(float64(100), jobPercent([]*models.KafkaJobProgress{
+		{StartOffset: 0, StopOffset: 0, ConsumedOffset: -1, Status: "done"},
+	}))
+}
+
+func TestGroupJobs(t *testing.T) {
+	assert := assert.New(t)
+
+	jobs := groupJobs([]*models.KafkaJobProgress{
+		{JobId: "b", WorkerGroup: "group-b", Topic: "blocks", Status: "done"},
+		{JobId: "a", WorkerGroup: "group-a", Topic: "blocks", Status: "running"},
+		{JobId: "b", WorkerGroup: "group-b", Topic: "logs", Status: "done"},
+	})
+	assert.Len(jobs, 2)
+	assert.Equal("a", jobs[0].JobID)
+	assert.Equal("group-a", jobs[0].WorkerGroup)
+	assert.Equal(models.KafkaJobStatusRunning, jobs[0].Status)
+	assert.Equal("b", jobs[1].JobID)
+	assert.Len(jobs[1].Partitions, 2)
+	assert.Equal(models.KafkaJobStatusDone, jobs[1].Status)
+}
+
+func TestJobTracker(t *testing.T) {
+	assert := assert.New(t)
+
+	tracker := newJobTracker([]*models.KafkaJobProgress{
+		{Topic: "blocks", Partition: 0, Status: "running"},
+		{Topic: "blocks", Partition: 1, Status: "done"},
+		{Topic: "logs", Partition: 0, Status: "pending"},
+	})
+	assert.False(tracker.isFinished("blocks", 0))
+	assert.True(tracker.isFinished("blocks", 1))
+
+	tracker.finish("blocks", 0)
+	tracker.finish("blocks", 0)
+	select {
+	case <-tracker.done:
+		assert.Fail("done before every partition finished")
+	default:
+	}
+
+	tracker.finish("logs", 0)
+	_, open := <-tracker.done
+	assert.False(open)
+
+	// Nothing left to consume
+	tracker = newJobTracker([]*models.KafkaJobProgress{{Topic: "blocks", Status: "cancelled"}})
+	_, open = <-tracker.done
+	assert.False(open)
+}
+
+func newTopicValue(t *testing.T, message proto.Message) []byte {
+	value, err := proto.Marshal(message)
+	assert.Nil(t, err)
+
+	return append(make([]byte, SchemaRegistryHeaderSize), value...)
+}
+
+func TestMessageBlockNumber(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.ConsumerTopicBlocks = "blocks"
+	config.Config.ConsumerTopicTransactions = "transactions"
+	config.Config.ConsumerTopicLogs = "logs"
+
+	blockNumber, err := messageBlockNumber("blocks", newTopicValue(t, &models.BlockRaw{Number: 10}))
+	assert.Nil(err)
+	assert.Equal(uint64(10), blockNumber)
+
+	blockNumber, err = messageBlockNumber("transactions", newTopicValue(t, &models.TransactionRaw{BlockNumber: 11}))
+	assert.Nil(err)
+	assert.Equal(uint64(11), blockNumber)
+
+	blockNumber, err = messageBlockNumber("logs", newTopicValue(t, &models.LogRaw{BlockNumber: 12}))
+	assert.Nil(err)
+	assert.Equal(uint64(12), blockNumber)
+
+	_, err = messageBlockNumber("unknown", newTopicValue(t, &models.LogRaw{BlockNumber: 12}))
+	assert.NotNil(err)
+
+	_, err = messageBlockNumber("blocks", []byte{0, 0})
+	assert.NotNil(err)
+}
+
+func TestStopPosition(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.ConsumerTopicBlocks = "blocks"
+
+	message := func(offset int64, blockNumber uint32) *sarama.ConsumerMessage {
+		return &sarama.ConsumerMessage{
+			Topic:  "blocks",
+			Offset: offset,
+			Value:  newTopicValue(t, &models.BlockRaw{Number: blockNumber}),
+		}
+	}
+
+	// Stop offset
+	jobPartition := &models.KafkaJobProgress{Topic: "blocks", StopOffset: 100}
+	assert.False(isPastStop(jobPartition, message(99, 1000)))
+	assert.False(isPastStop(jobPartition, message(100, 1000)))
+	assert.True(isAtStop(jobPartition, message(100, 1000)))
+	assert.True(isPastStop(jobPartition, message(101, 1000)))
+
+	// Stop block number
+	jobPartition.StopBlockNumber = 500
+	assert.False(isPastStop(jobPartition, message(10, 499)))
+	assert.False(isPastStop(jobPartition, message(11, 500)))
+	assert.True(isPastStop(jobPartition, message(12, 501)))
+	assert.True(isPastStop(jobPartition, message(101, 400)))
+}
+
+func TestWaitForDrain(t *testing.T) {
+	assert := assert.New(t)
+
+	// Message in a transformer
+	global.AddInFlight()
+
+	drained := make(chan bool)
+	go func() {
+		drained <- waitForDrain(context.Background())
+	}()
+
+	// Transformer sent a row to a loader
+	global.AddInFlight()
+	global.DoneInFlight()
+
+	select {
+	case <-drained:
+		assert.Fail("drained with a row in a loader")
+	case <-time.After(2 * drainPollInterval):
+	}
+
+	// Loader upserted the row
+	global.DoneInFlight()
+
+	select {
+	case ok := <-drained:
+		assert.True(ok)
+	case <-time.After(4 * drainPollInterval):
+		assert.Fail("not drained after the last row was loaded")
+	}
+
+	// Cancelled while rows are in flight
+	global.AddInFlight()
+	defer global.DoneInFlight()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(waitForDrain(ctx))
+}
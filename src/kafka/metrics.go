@@ -0,0 +1,23 @@
+package kafka
+
+import (
+	"strconv"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/geometry-labs/icon-blocks/metrics"
+)
+
+// setConsumerLag - messages left in the partition after msg
+// NOTE the high water mark is the offset of the next message to be produced
+func setConsumerLag(msg *sarama.ConsumerMessage, highWaterMark int64) {
+	lag := highWaterMark - msg.Offset - 1
+	if lag < 0 {
+		lag = 0
+	}
+
+	metrics.KafkaConsumerLagGauge.WithLabelValues(
+		msg.Topic,
+		strconv.Itoa(int(msg.Partition)),
+	).Set(float64(lag))
+}
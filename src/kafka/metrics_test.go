@@ -0,0 +1,33 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-blocks/metrics"
+)
+
+func TestSetConsumerLag(t *testing.T) {
+	assert := assert.New(t)
+
+	msg := &sarama.ConsumerMessage{
+		Topic:     "blocks",
+		Partition: 2,
+		Offset:    90,
+	}
+
+	// Messages after msg
+	setConsumerLag(msg, 100)
+	assert.Equal(float64(9), testutil.ToFloat64(metrics.KafkaConsumerLagGauge.WithLabelValues("blocks", "2")))
+
+	// Caught up
+	setConsumerLag(msg, 91)
+	assert.Equal(float64(0), testutil.ToFloat64(metrics.KafkaConsumerLagGauge.WithLabelValues("blocks", "2")))
+
+	// High water mark not known yet
+	setConsumerLag(msg, 0)
+	assert.Equal(float64(0), testutil.ToFloat64(metrics.KafkaConsumerLagGauge.WithLabelValues("blocks", "2")))
+}
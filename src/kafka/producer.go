@@ -0,0 +1,87 @@
+package kafka
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Shopify/sarama"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/models"
+)
+
+// BlockProducer - publishes enriched blocks to every PRODUCER_TOPICS topic
+type BlockProducer struct {
+	producer  sarama.SyncProducer
+	schemaIDs map[string]uint32
+}
+
+// NewBlockProducer - connect to the brokers and resolve the schema id of every producer topic
+// NOTE schema names of the topics come from SCHEMA_NAME_TOPICS
+func NewBlockProducer() (*BlockProducer, error) {
+	schemaRegistry := newSchemaRegistryClient(config.Config.SchemaRegistryURL)
+
+	schemaIDs := map[string]uint32{}
+	for _, topic := range config.Config.ProducerTopics {
+		schemaName, ok := config.Config.SchemaNameTopics[topic]
+		if !ok {
+			return nil, fmt.Errorf("topic %s has no schema name in SCHEMA_NAME_TOPICS", topic)
+		}
+
+		schemaID, err := schemaRegistry.getValueSchemaID(topic, schemaName)
+		if err != nil {
+			return nil, err
+		}
+		schemaIDs[topic] = schemaID
+	}
+
+	// Version, TLS and SASL
+	saramaConfig, err := newSaramaConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// Delivery
+	saramaConfig.Producer.RequiredAcks = sarama.WaitForAll
+	saramaConfig.Producer.Return.Successes = true
+
+	producer, err := sarama.NewSyncProducer(brokers(), saramaConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlockProducer{
+		producer:  producer,
+		schemaIDs: schemaIDs,
+	}, nil
+}
+
+// Produce - publish block to every producer topic, keyed by block number
+// Returns once every topic acknowledged the block
+func (p *BlockProducer) Produce(block *models.Block) error {
+	value, err := proto.Marshal(block)
+	if err != nil {
+		return err
+	}
+
+	messages := []*sarama.ProducerMessage{}
+	for _, topic := range config.Config.ProducerTopics {
+		messages = append(messages, newBlockMessage(topic, p.schemaIDs[topic], block.Number, value))
+	}
+
+	return p.producer.SendMessages(messages)
+}
+
+// Close - flush and close the producer
+func (p *BlockProducer) Close() error {
+	return p.producer.Close()
+}
+
+func newBlockMessage(topic string, schemaID uint32, number uint32, value []byte) *sarama.ProducerMessage {
+	return &sarama.ProducerMessage{
+		Topic: topic,
+		Key:   sarama.StringEncoder(strconv.FormatUint(uint64(number), 10)),
+		Value: sarama.ByteEncoder(frameValue(schemaID, value)),
+	}
+}
@@ -0,0 +1,125 @@
+package kafka
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/geometry-labs/icon-blocks/config"
+)
+
+// SchemaRegistryHeaderSize - bytes before the protobuf message in a topic value
+// NOTE magic byte, 4 byte schema id and the message index, skipped by the transformers
+const SchemaRegistryHeaderSize = 6
+
+// schemaRegistryTimeout - timeout of a schema registry request
+const schemaRegistryTimeout = 10 * time.Second
+
+// wireSchemaFolder - folder in SCHEMA_FOLDER_PATH with the schemas of published messages
+// NOTE wire schemas have no imports, the gorm options of the model schemas are not registered
+const wireSchemaFolder = "wire"
+
+// schemaRegistryClient - resolves the schema ids of topic values
+type schemaRegistryClient struct {
+	url        string
+	httpClient *http.Client
+}
+
+func newSchemaRegistryClient(registryURL string) *schemaRegistryClient {
+	// NOTE SCHEMA_REGISTRY_URL defaults to a host and port
+	if strings.Contains(registryURL, "://") == false {
+		registryURL = "http://" + registryURL
+	}
+
+	return &schemaRegistryClient{
+		url:        strings.TrimSuffix(registryURL, "/"),
+		httpClient: &http.Client{Timeout: schemaRegistryTimeout},
+	}
+}
+
+// schemaResponse - id of a registered schema
+type schemaResponse struct {
+	ID uint32 `json:"id"`
+}
+
+// getValueSchemaID - id of the latest value schema of topic
+// The schema file SCHEMA_FOLDER_PATH/wire/<schemaName>.proto is registered if the subject has no versions yet
+func (c *schemaRegistryClient) getValueSchemaID(topic string, schemaName string) (uint32, error) {
+	subject := url.PathEscape(topic + "-value")
+
+	// Latest version
+	resp, err := c.httpClient.Get(c.url + "/subjects/" + subject + "/versions/latest")
+	if err != nil {
+		return 0, err
+	}
+	schema, err := readSchemaResponse(resp)
+	if err == nil {
+		return schema.ID, nil
+	} else if resp.StatusCode != http.StatusNotFound {
+		return 0, fmt.Errorf("subject %s: %s", subject, err.Error())
+	}
+
+	// Register
+	schemaFile, err := ioutil.ReadFile(filepath.Join(config.Config.SchemaFolderPath, wireSchemaFolder, schemaName+".proto"))
+	if err != nil {
+		return 0, err
+	}
+	body, err := json.Marshal(map[string]string{
+		"schemaType": "PROTOBUF",
+		"schema":     string(schemaFile),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err = c.httpClient.Post(
+		c.url+"/subjects/"+subject+"/versions",
+		"application/vnd.schemaregistry.v1+json",
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return 0, err
+	}
+	schema, err = readSchemaResponse(resp)
+	if err != nil {
+		return 0, fmt.Errorf("registering subject %s: %s", subject, err.Error())
+	}
+
+	return schema.ID, nil
+}
+
+func readSchemaResponse(resp *http.Response) (*schemaResponse, error) {
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("schema registry responded %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	schema := &schemaResponse{}
+	err = json.Unmarshal(body, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// frameValue - topic value of a protobuf message with its schema id
+// Magic byte 0, the 4 byte schema id and the message index 0, the first message of the schema
+func frameValue(schemaID uint32, message []byte) []byte {
+	value := make([]byte, SchemaRegistryHeaderSize, SchemaRegistryHeaderSize+len(message))
+	binary.BigEndian.PutUint32(value[1:5], schemaID)
+
+	return append(value, message...)
+}
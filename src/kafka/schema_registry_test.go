@@ -0,0 +1,118 @@
+package kafka
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/models"
+)
+
+func TestGetValueSchemaID(t *testing.T) {
+	assert := assert.New(t)
+
+	// Schemas shipped with the service
+	config.Config.SchemaFolderPath = "../schemas"
+	wireSchema, err := ioutil.ReadFile("../schemas/wire/block.proto")
+	assert.Nil(err)
+
+	requests := []string{}
+	registered := map[string]map[string]string{}
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/subjects/blocks-ws-value/versions/latest":
+			w.Write([]byte(`{"subject":"blocks-ws-value","version":3,"id":42}`))
+		case r.Method == "POST" && r.URL.Path == "/subjects/blocks-new-value/versions":
+			body := map[string]string{}
+			json.NewDecoder(r.Body).Decode(&body)
+			registered["blocks-new-value"] = body
+			w.Write([]byte(`{"id":43}`))
+		case r.Method == "GET":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error_code":40401,"message":"Subject not found."}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer registry.Close()
+
+	client := newSchemaRegistryClient(registry.URL)
+
+	// Registered subject
+	schemaID, err := client.getValueSchemaID("blocks-ws", "block")
+	assert.Nil(err)
+	assert.Equal(uint32(42), schemaID)
+
+	// Missing subject is registered with the wire schema
+	requests = []string{}
+	schemaID, err = client.getValueSchemaID("blocks-new", "block")
+	assert.Nil(err)
+	assert.Equal(uint32(43), schemaID)
+	assert.Equal([]string{
+		"GET /subjects/blocks-new-value/versions/latest",
+		"POST /subjects/blocks-new-value/versions",
+	}, requests)
+	assert.Equal("PROTOBUF", registered["blocks-new-value"]["schemaType"])
+	assert.Equal(string(wireSchema), registered["blocks-new-value"]["schema"])
+
+	// Missing schema file
+	_, err = client.getValueSchemaID("blocks-other", "unknown")
+	assert.NotNil(err)
+}
+
+func TestWireSchemaBlock(t *testing.T) {
+	assert := assert.New(t)
+
+	wireSchema, err := ioutil.ReadFile("../schemas/wire/block.proto")
+	assert.Nil(err)
+
+	// Registered without references
+	assert.NotContains(string(wireSchema), "import")
+	assert.NotContains(string(wireSchema), "gorm")
+
+	// Same fields as the published model
+	wireFields := map[string]string{}
+	for _, match := range regexp.MustCompile(`(?m)^\s*\w+\s+(\w+)\s*=\s*(\d+);`).FindAllStringSubmatch(string(wireSchema), -1) {
+		wireFields[match[1]] = match[2]
+	}
+
+	fields := (&models.Block{}).ProtoReflect().Descriptor().Fields()
+	assert.Equal(fields.Len(), len(wireFields))
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		assert.Equal(strconv.Itoa(int(field.Number())), wireFields[string(field.Name())], string(field.Name()))
+	}
+}
+
+func TestNewSchemaRegistryClient(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("http://schemaregistry:8081", newSchemaRegistryClient("schemaregistry:8081").url)
+	assert.Equal("https://registry.example.com", newSchemaRegistryClient("https://registry.example.com/").url)
+}
+
+func TestFrameValue(t *testing.T) {
+	assert := assert.New(t)
+
+	value := frameValue(258, []byte("message"))
+	assert.Equal(byte(0), value[0])
+	assert.Equal(uint32(258), binary.BigEndian.Uint32(value[1:5]))
+	assert.Equal(byte(0), value[5])
+	assert.Equal("message", string(value[SchemaRegistryHeaderSize:]))
+
+	// Key is the block number
+	msg := newBlockMessage("blocks-ws", 258, 123, []byte("message"))
+	key, _ := msg.Key.Encode()
+	assert.Equal("123", string(key))
+	assert.Equal("blocks-ws", msg.Topic)
+}
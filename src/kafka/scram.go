@@ -0,0 +1,39 @@
+package kafka
+
+import (
+	"crypto/sha512"
+	"hash"
+
+	"github.com/xdg-go/scram"
+)
+
+// scramSHA512 - SHA-512 hash for SCRAM-SHA-512
+// NOTE scram only provides SHA-1 and SHA-256
+var scramSHA512 scram.HashGeneratorFcn = func() hash.Hash { return sha512.New() }
+
+// scramClient - sarama SCRAM client over xdg-go/scram
+type scramClient struct {
+	hashGenerator scram.HashGeneratorFcn
+	conversation  *scram.ClientConversation
+}
+
+// Begin - start a conversation for the user
+func (c *scramClient) Begin(userName, password, authzID string) error {
+	client, err := c.hashGenerator.NewClient(userName, password, authzID)
+	if err != nil {
+		return err
+	}
+
+	c.conversation = client.NewConversation()
+	return nil
+}
+
+// Step - next client message from the server challenge
+func (c *scramClient) Step(challenge string) (string, error) {
+	return c.conversation.Step(challenge)
+}
+
+// Done - true once the server is verified
+func (c *scramClient) Done() bool {
+	return c.conversation.Done()
+}
@@ -0,0 +1,31 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/geometry-labs/icon-blocks/tracing"
+)
+
+// startConsumeSpan - span for a consumed message, continuing the producer trace if present
+// NOTE the span context is written back to the message headers for the transformers
+func startConsumeSpan(ctx context.Context, msg *sarama.ConsumerMessage) trace.Span {
+	ctx, span := tracing.Start(
+		tracing.ExtractKafkaHeaders(ctx, msg),
+		"kafka.consume "+msg.Topic,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination", msg.Topic),
+			attribute.Int64("messaging.kafka.partition", int64(msg.Partition)),
+			attribute.Int64("messaging.kafka.offset", msg.Offset),
+		),
+	)
+
+	tracing.InjectKafkaHeaders(ctx, msg)
+
+	return span
+}
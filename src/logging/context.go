@@ -0,0 +1,34 @@
+package logging
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type requestIDContextKey struct{}
+
+// WithRequestID - attach a request ID to a context
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestIDFromContext - request ID attached to a context, empty if none
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+// FromContext - global logger with the request ID of the context as a field
+func FromContext(ctx context.Context) *zap.SugaredLogger {
+	requestID := RequestIDFromContext(ctx)
+	if requestID == "" {
+		return zap.S()
+	}
+
+	return zap.S().With("request_id", requestID)
+}
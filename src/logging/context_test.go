@@ -0,0 +1,24 @@
+package logging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIDContext(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+	assert.Equal("", RequestIDFromContext(ctx))
+	assert.Equal("", RequestIDFromContext(nil))
+
+	ctx = WithRequestID(ctx, "c5a8e0b2")
+	assert.Equal("c5a8e0b2", RequestIDFromContext(ctx))
+
+	// Derived contexts keep the request ID
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	assert.Equal("c5a8e0b2", RequestIDFromContext(ctx))
+}
@@ -11,6 +11,15 @@ import (
 	"github.com/geometry-labs/icon-blocks/global"
 )
 
+// level - level of the global logger
+// NOTE shared so the admin server can change it at runtime
+var level = zap.NewAtomicLevel()
+
+// Level - level of the global logger
+func Level() zap.AtomicLevel {
+	return level
+}
+
 // Init - init logging config
 func Init() {
 
@@ -67,31 +76,29 @@ func newLoggerEncoderConfig() zapcore.EncoderConfig {
 }
 
 func setLoggerConfigLogLevel() zap.AtomicLevel {
-	var atomicLevel zap.AtomicLevel
-
 	switch strings.ToUpper(config.Config.LogLevel) {
 	case "PANIC":
-		atomicLevel = zap.NewAtomicLevelAt(zap.PanicLevel)
+		level.SetLevel(zap.PanicLevel)
 		break
 	case "FATAL":
-		atomicLevel = zap.NewAtomicLevelAt(zap.FatalLevel)
+		level.SetLevel(zap.FatalLevel)
 		break
 	case "ERROR":
-		atomicLevel = zap.NewAtomicLevelAt(zap.ErrorLevel)
+		level.SetLevel(zap.ErrorLevel)
 		break
 	case "WARN":
-		atomicLevel = zap.NewAtomicLevelAt(zap.WarnLevel)
+		level.SetLevel(zap.WarnLevel)
 		break
 	case "INFO":
-		atomicLevel = zap.NewAtomicLevelAt(zap.InfoLevel)
+		level.SetLevel(zap.InfoLevel)
 		break
 	case "DEBUG":
-		atomicLevel = zap.NewAtomicLevelAt(zap.DebugLevel)
+		level.SetLevel(zap.DebugLevel)
 		break
 	default:
-		atomicLevel = zap.NewAtomicLevelAt(zap.DebugLevel)
+		level.SetLevel(zap.DebugLevel)
 	}
-	return atomicLevel
+	return level
 }
 
 func setLoggerConfigOutputPaths() []string {
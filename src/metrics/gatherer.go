@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/geometry-labs/icon-blocks/config"
+)
+
+const networkNameLabel = "network_name"
+
+// networkGatherer - sets the network_name label on every gathered metric
+// NOTE metrics are created before the config is read, so their network_name const label is empty
+type networkGatherer struct {
+	gatherer prometheus.Gatherer
+}
+
+// Gather - gather and label metric families
+func (g networkGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.gatherer.Gather()
+
+	for _, family := range families {
+		for _, metric := range family.Metric {
+			metric.Label = withNetworkName(metric.Label, config.Config.NetworkName)
+		}
+	}
+
+	return families, err
+}
+
+// withNetworkName - labels with network_name set to networkName
+// NOTE labels stay sorted by name
+func withNetworkName(labels []*dto.LabelPair, networkName string) []*dto.LabelPair {
+	for _, label := range labels {
+		if label.GetName() == networkNameLabel {
+			label.Value = proto.String(networkName)
+			return labels
+		}
+	}
+
+	networkLabel := &dto.LabelPair{
+		Name:  proto.String(networkNameLabel),
+		Value: proto.String(networkName),
+	}
+
+	for i, label := range labels {
+		if label.GetName() > networkNameLabel {
+			labels = append(labels[:i], append([]*dto.LabelPair{networkLabel}, labels[i:]...)...)
+			return labels
+		}
+	}
+
+	return append(labels, networkLabel)
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-blocks/config"
+)
+
+func TestNetworkGatherer(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.NetworkName = "mainnet"
+
+	registry := prometheus.NewRegistry()
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name:        "test_gauge",
+		ConstLabels: prometheus.Labels{"network_name": ""},
+	}, []string{"table", "builder"})
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "test_counter",
+	})
+	registry.MustRegister(gauge, counter)
+
+	gauge.WithLabelValues("blocks", "tail").Set(1)
+	counter.Inc()
+
+	families, err := networkGatherer{registry}.Gather()
+	assert.Equal(nil, err)
+	assert.Equal(2, len(families))
+
+	for _, family := range families {
+		labels := map[string]string{}
+		names := []string{}
+		for _, label := range family.Metric[0].Label {
+			labels[label.GetName()] = label.GetValue()
+			names = append(names, label.GetName())
+		}
+
+		// Empty const label is replaced, missing label is added
+		assert.Equal("mainnet", labels["network_name"])
+
+		// Labels stay sorted
+		if family.GetName() == "test_gauge" {
+			assert.Equal([]string{"builder", "network_name", "table"}, names)
+		}
+	}
+}
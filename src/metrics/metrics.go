@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"net/http"
+	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -28,12 +29,136 @@ var (
 		Help:        "max block number read from the logs_raw topic",
 		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
 	})
+	BroadcasterSubscribersGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name:        "broadcaster_subscribers",
+		Help:        "number of channels subscribed to the redis broadcaster",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	})
+	BroadcasterDroppedMessagesCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name:        "broadcaster_dropped_messages_total",
+		Help:        "messages not delivered to a subscriber because its buffer was full",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	})
+	BroadcasterDisconnectedSubscribersCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name:        "broadcaster_disconnected_subscribers_total",
+		Help:        "subscribers removed from the broadcaster for being too slow",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	})
+	BroadcasterFanOutLatencyHistogram = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:        "broadcaster_fan_out_latency_seconds",
+		Help:        "time taken to fan a message out to all broadcaster subscribers",
+		Buckets:     prometheus.ExponentialBuckets(0.0001, 4, 8),
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	})
+	RateLimitRejectedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:        "rate_limit_rejected_requests_total",
+		Help:        "requests rejected by the API rate limiter",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"tier"})
+	CrudQueryTimeoutsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:        "crud_query_timeouts_total",
+		Help:        "crud reads cancelled after the statement timeout",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"query"})
+	CrudQueriesRejectedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:        "crud_queries_rejected_total",
+		Help:        "crud reads refused by the query cost guard",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"query"})
+	KafkaConsumerLagGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:        "kafka_consumer_lag",
+		Help:        "messages between the last consumed offset and the partition high water mark",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"topic", "partition"})
+	KafkaJobProgressGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:        "kafka_job_progress_percent",
+		Help:        "percent of the messages of a kafka job consumed",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"job_id"})
+	TransformerMessagesProcessedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:        "transformer_messages_processed_total",
+		Help:        "kafka messages processed by each transformer",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"transformer"})
+	LoaderUpsertLatencyHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:        "loader_upsert_latency_seconds",
+		Help:        "time taken by a loader to upsert one row",
+		Buckets:     prometheus.ExponentialBuckets(0.0005, 2, 14),
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"table"})
+	BuilderBlockNumberGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:        "builder_block_number",
+		Help:        "next block number each builder will compute",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"builder"})
+	BuilderBlocksBehindHeadGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:        "builder_blocks_behind_head",
+		Help:        "blocks between each builder and the latest block in postgres",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"builder"})
+	TableBlocksBehindHeadGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:        "table_blocks_behind_head",
+		Help:        "blocks between each derived table and the chain head",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"table"})
+	TableSecondsBehindHeadGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:        "table_seconds_behind_head",
+		Help:        "age of the last block reached by each derived table",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"table"})
+	WebsocketSubscribersGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:        "websocket_subscribers",
+		Help:        "open websocket connections per redis channel",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"channel"})
 )
 
+// loaderChannelDepths - depth of every registered loader channel by table
+var loaderChannelDepths = struct {
+	sync.Mutex
+	depths map[string]func() int
+}{depths: map[string]func() int{}}
+
+// RegisterLoaderChannelDepth - gauge reporting the number of rows waiting in a loader channel
+// NOTE call once per table
+func RegisterLoaderChannelDepth(table string, depth func() int) {
+	loaderChannelDepths.Lock()
+	loaderChannelDepths.depths[table] = depth
+	loaderChannelDepths.Unlock()
+
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "loader_channel_depth",
+		Help: "rows waiting in each loader channel",
+		ConstLabels: prometheus.Labels{
+			"network_name": config.Config.NetworkName,
+			"table":        table,
+		},
+	}, func() float64 {
+		return float64(depth())
+	})
+}
+
+// LoaderChannelDepths - rows currently waiting in each registered loader channel
+func LoaderChannelDepths() map[string]int {
+	loaderChannelDepths.Lock()
+	defer loaderChannelDepths.Unlock()
+
+	depths := make(map[string]int, len(loaderChannelDepths.depths))
+	for table, depth := range loaderChannelDepths.depths {
+		depths[table] = depth()
+	}
+
+	return depths
+}
+
 func Start() {
 
 	// Start server
-	http.Handle(config.Config.MetricsPrefix, promhttp.Handler())
+	handler := promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(networkGatherer{prometheus.DefaultGatherer}, promhttp.HandlerOpts{}),
+	)
+	http.Handle(config.Config.MetricsPrefix, handler)
 	go http.ListenAndServe(":"+config.Config.MetricsPort, nil)
 	zap.S().Info("Started Metrics:", config.Config.MetricsPort)
 }
@@ -0,0 +1,214 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: api_key.proto
+
+package models
+
+import (
+	context "context"
+	fmt "fmt"
+	
+	_ "github.com/infobloxopen/protoc-gen-gorm/options"
+	math "math"
+
+	gorm2 "github.com/infobloxopen/atlas-app-toolkit/gorm"
+	errors1 "github.com/infobloxopen/protoc-gen-gorm/errors"
+	gorm1 "github.com/jinzhu/gorm"
+	field_mask1 "google.golang.org/genproto/protobuf/field_mask"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type ApiKeyORM struct {
+	IsActive bool
+	KeyHash  string `gorm:"primary_key"`
+	Name     string
+	Tier     string
+}
+
+// TableName overrides the default tablename generated by GORM
+func (ApiKeyORM) TableName() string {
+	return "api_keys"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *ApiKey) ToORM(ctx context.Context) (ApiKeyORM, error) {
+	to := ApiKeyORM{}
+	var err error
+	if prehook, ok := interface{}(m).(ApiKeyWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.KeyHash = m.KeyHash
+	to.Name = m.Name
+	to.Tier = m.Tier
+	to.IsActive = m.IsActive
+	if posthook, ok := interface{}(m).(ApiKeyWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *ApiKeyORM) ToPB(ctx context.Context) (ApiKey, error) {
+	to := ApiKey{}
+	var err error
+	if prehook, ok := interface{}(m).(ApiKeyWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.KeyHash = m.KeyHash
+	to.Name = m.Name
+	to.Tier = m.Tier
+	to.IsActive = m.IsActive
+	if posthook, ok := interface{}(m).(ApiKeyWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type ApiKey the arg will be the target, the caller the one being converted from
+
+// ApiKeyBeforeToORM called before default ToORM code
+type ApiKeyWithBeforeToORM interface {
+	BeforeToORM(context.Context, *ApiKeyORM) error
+}
+
+// ApiKeyAfterToORM called after default ToORM code
+type ApiKeyWithAfterToORM interface {
+	AfterToORM(context.Context, *ApiKeyORM) error
+}
+
+// ApiKeyBeforeToPB called before default ToPB code
+type ApiKeyWithBeforeToPB interface {
+	BeforeToPB(context.Context, *ApiKey) error
+}
+
+// ApiKeyAfterToPB called after default ToPB code
+type ApiKeyWithAfterToPB interface {
+	AfterToPB(context.Context, *ApiKey) error
+}
+
+// DefaultCreateApiKey executes a basic gorm create call
+func DefaultCreateApiKey(ctx context.Context, in *ApiKey, db *gorm1.DB) (*ApiKey, error) {
+	if in == nil {
+		return nil, errors1.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(ApiKeyORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(ApiKeyORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type ApiKeyORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type ApiKeyORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm1.DB) error
+}
+
+// DefaultApplyFieldMaskApiKey patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskApiKey(ctx context.Context, patchee *ApiKey, patcher *ApiKey, updateMask *field_mask1.FieldMask, prefix string, db *gorm1.DB) (*ApiKey, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors1.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"KeyHash" {
+			patchee.KeyHash = patcher.KeyHash
+			continue
+		}
+		if f == prefix+"Name" {
+			patchee.Name = patcher.Name
+			continue
+		}
+		if f == prefix+"Tier" {
+			patchee.Tier = patcher.Tier
+			continue
+		}
+		if f == prefix+"IsActive" {
+			patchee.IsActive = patcher.IsActive
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListApiKey executes a gorm list call
+func DefaultListApiKey(ctx context.Context, db *gorm1.DB) ([]*ApiKey, error) {
+	in := ApiKey{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(ApiKeyORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db, err = gorm2.ApplyCollectionOperators(ctx, db, &ApiKeyORM{}, &ApiKey{}, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(ApiKeyORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("key_hash")
+	ormResponse := []ApiKeyORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(ApiKeyORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*ApiKey{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type ApiKeyORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type ApiKeyORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type ApiKeyORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm1.DB, *[]ApiKeyORM) error
+}
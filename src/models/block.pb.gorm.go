@@ -23,7 +23,7 @@ var _ = math.Inf
 type BlockORM struct {
 	BlockTime                 uint64
 	FailedTransactionCount    uint32
-	Hash                      string
+	Hash                      string `gorm:"index:block_idx_hash"`
 	InternalTransactionAmount string
 	InternalTransactionCount  uint32
 	ItemId                    string
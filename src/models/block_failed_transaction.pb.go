@@ -76,6 +76,55 @@ func (x *BlockFailedTransaction) GetTransactionHash() string {
 	return ""
 }
 
+// GORM table to store all seen websocket messages
+// Used to avoid duplicate messages
+type BlockFailedTransactionWebsocketIndex struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionHash string `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash"`
+}
+
+func (x *BlockFailedTransactionWebsocketIndex) Reset() {
+	*x = BlockFailedTransactionWebsocketIndex{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_block_failed_transaction_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockFailedTransactionWebsocketIndex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockFailedTransactionWebsocketIndex) ProtoMessage() {}
+
+func (x *BlockFailedTransactionWebsocketIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_block_failed_transaction_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockFailedTransactionWebsocketIndex.ProtoReflect.Descriptor instead.
+func (*BlockFailedTransactionWebsocketIndex) Descriptor() ([]byte, []int) {
+	return file_block_failed_transaction_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BlockFailedTransactionWebsocketIndex) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
 var File_block_failed_transaction_proto protoreflect.FileDescriptor
 
 var file_block_failed_transaction_proto_rawDesc = []byte{
@@ -95,8 +144,14 @@ var file_block_failed_transaction_proto_rawDesc = []byte{
 	0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08,
 	0xba, 0xb9, 0x19, 0x04, 0x0a, 0x02, 0x28, 0x01, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
 	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x3a, 0x06, 0xba, 0xb9, 0x19, 0x02, 0x08,
-	0x01, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x01, 0x22, 0x63, 0x0a, 0x24, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x65, 0x62, 0x73, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x33, 0x0a, 0x10, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x08, 0xba, 0xb9, 0x19, 0x04, 0x0a, 0x02, 0x28, 0x01, 0x52, 0x0f, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x3a, 0x06,
+	0xba, 0xb9, 0x19, 0x02, 0x08, 0x01, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -111,9 +166,10 @@ func file_block_failed_transaction_proto_rawDescGZIP() []byte {
 	return file_block_failed_transaction_proto_rawDescData
 }
 
-var file_block_failed_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_block_failed_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_block_failed_transaction_proto_goTypes = []interface{}{
-	(*BlockFailedTransaction)(nil), // 0: models.BlockFailedTransaction
+	(*BlockFailedTransaction)(nil),               // 0: models.BlockFailedTransaction
+	(*BlockFailedTransactionWebsocketIndex)(nil), // 1: models.BlockFailedTransactionWebsocketIndex
 }
 var file_block_failed_transaction_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -141,6 +197,18 @@ func file_block_failed_transaction_proto_init() {
 				return nil
 			}
 		}
+		file_block_failed_transaction_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockFailedTransactionWebsocketIndex); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -148,7 +216,7 @@ func file_block_failed_transaction_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_block_failed_transaction_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
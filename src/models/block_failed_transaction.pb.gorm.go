@@ -198,3 +198,174 @@ type BlockFailedTransactionORMWithBeforeListFind interface {
 type BlockFailedTransactionORMWithAfterListFind interface {
 	AfterListFind(context.Context, *gorm1.DB, *[]BlockFailedTransactionORM) error
 }
+type BlockFailedTransactionWebsocketIndexORM struct {
+	TransactionHash string `gorm:"primary_key"`
+}
+
+// TableName overrides the default tablename generated by GORM
+func (BlockFailedTransactionWebsocketIndexORM) TableName() string {
+	return "block_failed_transaction_websocket_indices"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *BlockFailedTransactionWebsocketIndex) ToORM(ctx context.Context) (BlockFailedTransactionWebsocketIndexORM, error) {
+	to := BlockFailedTransactionWebsocketIndexORM{}
+	var err error
+	if prehook, ok := interface{}(m).(BlockFailedTransactionWebsocketIndexWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.TransactionHash = m.TransactionHash
+	if posthook, ok := interface{}(m).(BlockFailedTransactionWebsocketIndexWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *BlockFailedTransactionWebsocketIndexORM) ToPB(ctx context.Context) (BlockFailedTransactionWebsocketIndex, error) {
+	to := BlockFailedTransactionWebsocketIndex{}
+	var err error
+	if prehook, ok := interface{}(m).(BlockFailedTransactionWebsocketIndexWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.TransactionHash = m.TransactionHash
+	if posthook, ok := interface{}(m).(BlockFailedTransactionWebsocketIndexWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type BlockFailedTransactionWebsocketIndex the arg will be the target, the caller the one being converted from
+
+// BlockFailedTransactionWebsocketIndexBeforeToORM called before default ToORM code
+type BlockFailedTransactionWebsocketIndexWithBeforeToORM interface {
+	BeforeToORM(context.Context, *BlockFailedTransactionWebsocketIndexORM) error
+}
+
+// BlockFailedTransactionWebsocketIndexAfterToORM called after default ToORM code
+type BlockFailedTransactionWebsocketIndexWithAfterToORM interface {
+	AfterToORM(context.Context, *BlockFailedTransactionWebsocketIndexORM) error
+}
+
+// BlockFailedTransactionWebsocketIndexBeforeToPB called before default ToPB code
+type BlockFailedTransactionWebsocketIndexWithBeforeToPB interface {
+	BeforeToPB(context.Context, *BlockFailedTransactionWebsocketIndex) error
+}
+
+// BlockFailedTransactionWebsocketIndexAfterToPB called after default ToPB code
+type BlockFailedTransactionWebsocketIndexWithAfterToPB interface {
+	AfterToPB(context.Context, *BlockFailedTransactionWebsocketIndex) error
+}
+
+// DefaultCreateBlockFailedTransactionWebsocketIndex executes a basic gorm create call
+func DefaultCreateBlockFailedTransactionWebsocketIndex(ctx context.Context, in *BlockFailedTransactionWebsocketIndex, db *gorm1.DB) (*BlockFailedTransactionWebsocketIndex, error) {
+	if in == nil {
+		return nil, errors1.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BlockFailedTransactionWebsocketIndexORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BlockFailedTransactionWebsocketIndexORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type BlockFailedTransactionWebsocketIndexORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type BlockFailedTransactionWebsocketIndexORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm1.DB) error
+}
+
+// DefaultApplyFieldMaskBlockFailedTransactionWebsocketIndex patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskBlockFailedTransactionWebsocketIndex(ctx context.Context, patchee *BlockFailedTransactionWebsocketIndex, patcher *BlockFailedTransactionWebsocketIndex, updateMask *field_mask1.FieldMask, prefix string, db *gorm1.DB) (*BlockFailedTransactionWebsocketIndex, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors1.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"TransactionHash" {
+			patchee.TransactionHash = patcher.TransactionHash
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListBlockFailedTransactionWebsocketIndex executes a gorm list call
+func DefaultListBlockFailedTransactionWebsocketIndex(ctx context.Context, db *gorm1.DB) ([]*BlockFailedTransactionWebsocketIndex, error) {
+	in := BlockFailedTransactionWebsocketIndex{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BlockFailedTransactionWebsocketIndexORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db, err = gorm2.ApplyCollectionOperators(ctx, db, &BlockFailedTransactionWebsocketIndexORM{}, &BlockFailedTransactionWebsocketIndex{}, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BlockFailedTransactionWebsocketIndexORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("transaction_hash")
+	ormResponse := []BlockFailedTransactionWebsocketIndexORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BlockFailedTransactionWebsocketIndexORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*BlockFailedTransactionWebsocketIndex{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type BlockFailedTransactionWebsocketIndexORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type BlockFailedTransactionWebsocketIndexORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type BlockFailedTransactionWebsocketIndexORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm1.DB, *[]BlockFailedTransactionWebsocketIndexORM) error
+}
@@ -92,6 +92,63 @@ func (x *BlockInternalTransaction) GetAmount() string {
 	return ""
 }
 
+// GORM table to store all seen websocket messages
+// Used to avoid duplicate messages
+type BlockInternalTransactionWebsocketIndex struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionHash string `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash"`
+	LogIndex        uint32 `protobuf:"varint,2,opt,name=log_index,json=logIndex,proto3" json:"log_index"`
+}
+
+func (x *BlockInternalTransactionWebsocketIndex) Reset() {
+	*x = BlockInternalTransactionWebsocketIndex{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_block_internal_transaction_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockInternalTransactionWebsocketIndex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockInternalTransactionWebsocketIndex) ProtoMessage() {}
+
+func (x *BlockInternalTransactionWebsocketIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_block_internal_transaction_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockInternalTransactionWebsocketIndex.ProtoReflect.Descriptor instead.
+func (*BlockInternalTransactionWebsocketIndex) Descriptor() ([]byte, []int) {
+	return file_block_internal_transaction_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BlockInternalTransactionWebsocketIndex) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+func (x *BlockInternalTransactionWebsocketIndex) GetLogIndex() uint32 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
 var File_block_internal_transaction_proto protoreflect.FileDescriptor
 
 var file_block_internal_transaction_proto_rawDesc = []byte{
@@ -115,8 +172,17 @@ var file_block_internal_transaction_proto_rawDesc = []byte{
 	0x28, 0x0d, 0x42, 0x08, 0xba, 0xb9, 0x19, 0x04, 0x0a, 0x02, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x6f,
 	0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
 	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x3a, 0x06,
-	0xba, 0xb9, 0x19, 0x02, 0x08, 0x01, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x6d, 0x6f, 0x64, 0x65,
-	0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0xba, 0xb9, 0x19, 0x02, 0x08, 0x01, 0x22, 0x8c, 0x01, 0x0a, 0x26, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x33, 0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xba, 0xb9, 0x19,
+	0x04, 0x0a, 0x02, 0x28, 0x01, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x12, 0x25, 0x0a, 0x09, 0x6c, 0x6f, 0x67, 0x5f, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x08, 0xba, 0xb9, 0x19, 0x04, 0x0a,
+	0x02, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x6f, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x3a, 0x06, 0xba,
+	0xb9, 0x19, 0x02, 0x08, 0x01, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
+	0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -131,9 +197,10 @@ func file_block_internal_transaction_proto_rawDescGZIP() []byte {
 	return file_block_internal_transaction_proto_rawDescData
 }
 
-var file_block_internal_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_block_internal_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_block_internal_transaction_proto_goTypes = []interface{}{
-	(*BlockInternalTransaction)(nil), // 0: models.BlockInternalTransaction
+	(*BlockInternalTransaction)(nil),               // 0: models.BlockInternalTransaction
+	(*BlockInternalTransactionWebsocketIndex)(nil), // 1: models.BlockInternalTransactionWebsocketIndex
 }
 var file_block_internal_transaction_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -161,6 +228,18 @@ func file_block_internal_transaction_proto_init() {
 				return nil
 			}
 		}
+		file_block_internal_transaction_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockInternalTransactionWebsocketIndex); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -168,7 +247,7 @@ func file_block_internal_transaction_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_block_internal_transaction_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
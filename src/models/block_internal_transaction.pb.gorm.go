@@ -212,3 +212,181 @@ type BlockInternalTransactionORMWithBeforeListFind interface {
 type BlockInternalTransactionORMWithAfterListFind interface {
 	AfterListFind(context.Context, *gorm1.DB, *[]BlockInternalTransactionORM) error
 }
+type BlockInternalTransactionWebsocketIndexORM struct {
+	LogIndex        uint32 `gorm:"primary_key"`
+	TransactionHash string `gorm:"primary_key"`
+}
+
+// TableName overrides the default tablename generated by GORM
+func (BlockInternalTransactionWebsocketIndexORM) TableName() string {
+	return "block_internal_transaction_websocket_indices"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *BlockInternalTransactionWebsocketIndex) ToORM(ctx context.Context) (BlockInternalTransactionWebsocketIndexORM, error) {
+	to := BlockInternalTransactionWebsocketIndexORM{}
+	var err error
+	if prehook, ok := interface{}(m).(BlockInternalTransactionWebsocketIndexWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.TransactionHash = m.TransactionHash
+	to.LogIndex = m.LogIndex
+	if posthook, ok := interface{}(m).(BlockInternalTransactionWebsocketIndexWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *BlockInternalTransactionWebsocketIndexORM) ToPB(ctx context.Context) (BlockInternalTransactionWebsocketIndex, error) {
+	to := BlockInternalTransactionWebsocketIndex{}
+	var err error
+	if prehook, ok := interface{}(m).(BlockInternalTransactionWebsocketIndexWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.TransactionHash = m.TransactionHash
+	to.LogIndex = m.LogIndex
+	if posthook, ok := interface{}(m).(BlockInternalTransactionWebsocketIndexWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type BlockInternalTransactionWebsocketIndex the arg will be the target, the caller the one being converted from
+
+// BlockInternalTransactionWebsocketIndexBeforeToORM called before default ToORM code
+type BlockInternalTransactionWebsocketIndexWithBeforeToORM interface {
+	BeforeToORM(context.Context, *BlockInternalTransactionWebsocketIndexORM) error
+}
+
+// BlockInternalTransactionWebsocketIndexAfterToORM called after default ToORM code
+type BlockInternalTransactionWebsocketIndexWithAfterToORM interface {
+	AfterToORM(context.Context, *BlockInternalTransactionWebsocketIndexORM) error
+}
+
+// BlockInternalTransactionWebsocketIndexBeforeToPB called before default ToPB code
+type BlockInternalTransactionWebsocketIndexWithBeforeToPB interface {
+	BeforeToPB(context.Context, *BlockInternalTransactionWebsocketIndex) error
+}
+
+// BlockInternalTransactionWebsocketIndexAfterToPB called after default ToPB code
+type BlockInternalTransactionWebsocketIndexWithAfterToPB interface {
+	AfterToPB(context.Context, *BlockInternalTransactionWebsocketIndex) error
+}
+
+// DefaultCreateBlockInternalTransactionWebsocketIndex executes a basic gorm create call
+func DefaultCreateBlockInternalTransactionWebsocketIndex(ctx context.Context, in *BlockInternalTransactionWebsocketIndex, db *gorm1.DB) (*BlockInternalTransactionWebsocketIndex, error) {
+	if in == nil {
+		return nil, errors1.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BlockInternalTransactionWebsocketIndexORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BlockInternalTransactionWebsocketIndexORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type BlockInternalTransactionWebsocketIndexORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type BlockInternalTransactionWebsocketIndexORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm1.DB) error
+}
+
+// DefaultApplyFieldMaskBlockInternalTransactionWebsocketIndex patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskBlockInternalTransactionWebsocketIndex(ctx context.Context, patchee *BlockInternalTransactionWebsocketIndex, patcher *BlockInternalTransactionWebsocketIndex, updateMask *field_mask1.FieldMask, prefix string, db *gorm1.DB) (*BlockInternalTransactionWebsocketIndex, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors1.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"TransactionHash" {
+			patchee.TransactionHash = patcher.TransactionHash
+			continue
+		}
+		if f == prefix+"LogIndex" {
+			patchee.LogIndex = patcher.LogIndex
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListBlockInternalTransactionWebsocketIndex executes a gorm list call
+func DefaultListBlockInternalTransactionWebsocketIndex(ctx context.Context, db *gorm1.DB) ([]*BlockInternalTransactionWebsocketIndex, error) {
+	in := BlockInternalTransactionWebsocketIndex{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BlockInternalTransactionWebsocketIndexORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db, err = gorm2.ApplyCollectionOperators(ctx, db, &BlockInternalTransactionWebsocketIndexORM{}, &BlockInternalTransactionWebsocketIndex{}, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BlockInternalTransactionWebsocketIndexORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("transaction_hash")
+	ormResponse := []BlockInternalTransactionWebsocketIndexORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BlockInternalTransactionWebsocketIndexORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*BlockInternalTransactionWebsocketIndex{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type BlockInternalTransactionWebsocketIndexORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type BlockInternalTransactionWebsocketIndexORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type BlockInternalTransactionWebsocketIndexORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm1.DB, *[]BlockInternalTransactionWebsocketIndexORM) error
+}
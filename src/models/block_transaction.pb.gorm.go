@@ -212,3 +212,174 @@ type BlockTransactionORMWithBeforeListFind interface {
 type BlockTransactionORMWithAfterListFind interface {
 	AfterListFind(context.Context, *gorm1.DB, *[]BlockTransactionORM) error
 }
+type BlockTransactionWebsocketIndexORM struct {
+	TransactionHash string `gorm:"primary_key"`
+}
+
+// TableName overrides the default tablename generated by GORM
+func (BlockTransactionWebsocketIndexORM) TableName() string {
+	return "block_transaction_websocket_indices"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *BlockTransactionWebsocketIndex) ToORM(ctx context.Context) (BlockTransactionWebsocketIndexORM, error) {
+	to := BlockTransactionWebsocketIndexORM{}
+	var err error
+	if prehook, ok := interface{}(m).(BlockTransactionWebsocketIndexWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.TransactionHash = m.TransactionHash
+	if posthook, ok := interface{}(m).(BlockTransactionWebsocketIndexWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *BlockTransactionWebsocketIndexORM) ToPB(ctx context.Context) (BlockTransactionWebsocketIndex, error) {
+	to := BlockTransactionWebsocketIndex{}
+	var err error
+	if prehook, ok := interface{}(m).(BlockTransactionWebsocketIndexWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.TransactionHash = m.TransactionHash
+	if posthook, ok := interface{}(m).(BlockTransactionWebsocketIndexWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type BlockTransactionWebsocketIndex the arg will be the target, the caller the one being converted from
+
+// BlockTransactionWebsocketIndexBeforeToORM called before default ToORM code
+type BlockTransactionWebsocketIndexWithBeforeToORM interface {
+	BeforeToORM(context.Context, *BlockTransactionWebsocketIndexORM) error
+}
+
+// BlockTransactionWebsocketIndexAfterToORM called after default ToORM code
+type BlockTransactionWebsocketIndexWithAfterToORM interface {
+	AfterToORM(context.Context, *BlockTransactionWebsocketIndexORM) error
+}
+
+// BlockTransactionWebsocketIndexBeforeToPB called before default ToPB code
+type BlockTransactionWebsocketIndexWithBeforeToPB interface {
+	BeforeToPB(context.Context, *BlockTransactionWebsocketIndex) error
+}
+
+// BlockTransactionWebsocketIndexAfterToPB called after default ToPB code
+type BlockTransactionWebsocketIndexWithAfterToPB interface {
+	AfterToPB(context.Context, *BlockTransactionWebsocketIndex) error
+}
+
+// DefaultCreateBlockTransactionWebsocketIndex executes a basic gorm create call
+func DefaultCreateBlockTransactionWebsocketIndex(ctx context.Context, in *BlockTransactionWebsocketIndex, db *gorm1.DB) (*BlockTransactionWebsocketIndex, error) {
+	if in == nil {
+		return nil, errors1.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BlockTransactionWebsocketIndexORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BlockTransactionWebsocketIndexORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type BlockTransactionWebsocketIndexORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type BlockTransactionWebsocketIndexORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm1.DB) error
+}
+
+// DefaultApplyFieldMaskBlockTransactionWebsocketIndex patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskBlockTransactionWebsocketIndex(ctx context.Context, patchee *BlockTransactionWebsocketIndex, patcher *BlockTransactionWebsocketIndex, updateMask *field_mask1.FieldMask, prefix string, db *gorm1.DB) (*BlockTransactionWebsocketIndex, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors1.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"TransactionHash" {
+			patchee.TransactionHash = patcher.TransactionHash
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListBlockTransactionWebsocketIndex executes a gorm list call
+func DefaultListBlockTransactionWebsocketIndex(ctx context.Context, db *gorm1.DB) ([]*BlockTransactionWebsocketIndex, error) {
+	in := BlockTransactionWebsocketIndex{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BlockTransactionWebsocketIndexORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db, err = gorm2.ApplyCollectionOperators(ctx, db, &BlockTransactionWebsocketIndexORM{}, &BlockTransactionWebsocketIndex{}, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BlockTransactionWebsocketIndexORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("transaction_hash")
+	ormResponse := []BlockTransactionWebsocketIndexORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BlockTransactionWebsocketIndexORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*BlockTransactionWebsocketIndex{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type BlockTransactionWebsocketIndexORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type BlockTransactionWebsocketIndexORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type BlockTransactionWebsocketIndexORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm1.DB, *[]BlockTransactionWebsocketIndexORM) error
+}
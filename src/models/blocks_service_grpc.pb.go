@@ -0,0 +1,211 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.6.1
+// source: blocks_service.proto
+
+package models
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// BlocksServiceClient is the client API for BlocksService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BlocksServiceClient interface {
+	// Block details by number
+	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error)
+	// Historical blocks, same filters as GET /blocks
+	ListBlocks(ctx context.Context, in *ListBlocksRequest, opts ...grpc.CallOption) (*ListBlocksResponse, error)
+	// New blocks as they are loaded
+	StreamBlocks(ctx context.Context, in *StreamBlocksRequest, opts ...grpc.CallOption) (BlocksService_StreamBlocksClient, error)
+}
+
+type blocksServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBlocksServiceClient(cc grpc.ClientConnInterface) BlocksServiceClient {
+	return &blocksServiceClient{cc}
+}
+
+func (c *blocksServiceClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error) {
+	out := new(Block)
+	err := c.cc.Invoke(ctx, "/models.BlocksService/GetBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blocksServiceClient) ListBlocks(ctx context.Context, in *ListBlocksRequest, opts ...grpc.CallOption) (*ListBlocksResponse, error) {
+	out := new(ListBlocksResponse)
+	err := c.cc.Invoke(ctx, "/models.BlocksService/ListBlocks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blocksServiceClient) StreamBlocks(ctx context.Context, in *StreamBlocksRequest, opts ...grpc.CallOption) (BlocksService_StreamBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BlocksService_ServiceDesc.Streams[0], "/models.BlocksService/StreamBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blocksServiceStreamBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlocksService_StreamBlocksClient interface {
+	Recv() (*BlockWebsocket, error)
+	grpc.ClientStream
+}
+
+type blocksServiceStreamBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *blocksServiceStreamBlocksClient) Recv() (*BlockWebsocket, error) {
+	m := new(BlockWebsocket)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BlocksServiceServer is the server API for BlocksService service.
+// All implementations must embed UnimplementedBlocksServiceServer
+// for forward compatibility
+type BlocksServiceServer interface {
+	// Block details by number
+	GetBlock(context.Context, *GetBlockRequest) (*Block, error)
+	// Historical blocks, same filters as GET /blocks
+	ListBlocks(context.Context, *ListBlocksRequest) (*ListBlocksResponse, error)
+	// New blocks as they are loaded
+	StreamBlocks(*StreamBlocksRequest, BlocksService_StreamBlocksServer) error
+	mustEmbedUnimplementedBlocksServiceServer()
+}
+
+// UnimplementedBlocksServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedBlocksServiceServer struct {
+}
+
+func (UnimplementedBlocksServiceServer) GetBlock(context.Context, *GetBlockRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (UnimplementedBlocksServiceServer) ListBlocks(context.Context, *ListBlocksRequest) (*ListBlocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBlocks not implemented")
+}
+func (UnimplementedBlocksServiceServer) StreamBlocks(*StreamBlocksRequest, BlocksService_StreamBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamBlocks not implemented")
+}
+func (UnimplementedBlocksServiceServer) mustEmbedUnimplementedBlocksServiceServer() {}
+
+// UnsafeBlocksServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BlocksServiceServer will
+// result in compilation errors.
+type UnsafeBlocksServiceServer interface {
+	mustEmbedUnimplementedBlocksServiceServer()
+}
+
+func RegisterBlocksServiceServer(s grpc.ServiceRegistrar, srv BlocksServiceServer) {
+	s.RegisterService(&BlocksService_ServiceDesc, srv)
+}
+
+func _BlocksService_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlocksServiceServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/models.BlocksService/GetBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlocksServiceServer).GetBlock(ctx, req.(*GetBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlocksService_ListBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlocksServiceServer).ListBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/models.BlocksService/ListBlocks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlocksServiceServer).ListBlocks(ctx, req.(*ListBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlocksService_StreamBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamBlocksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlocksServiceServer).StreamBlocks(m, &blocksServiceStreamBlocksServer{stream})
+}
+
+type BlocksService_StreamBlocksServer interface {
+	Send(*BlockWebsocket) error
+	grpc.ServerStream
+}
+
+type blocksServiceStreamBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *blocksServiceStreamBlocksServer) Send(m *BlockWebsocket) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// BlocksService_ServiceDesc is the grpc.ServiceDesc for BlocksService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BlocksService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "models.BlocksService",
+	HandlerType: (*BlocksServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBlock",
+			Handler:    _BlocksService_GetBlock_Handler,
+		},
+		{
+			MethodName: "ListBlocks",
+			Handler:    _BlocksService_ListBlocks_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamBlocks",
+			Handler:       _BlocksService_StreamBlocks_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "blocks_service.proto",
+}
@@ -0,0 +1,10 @@
+package models
+
+// Kafka job partition statuses
+// NOTE values of KafkaJobProgress.Status
+const (
+	KafkaJobStatusPending   = "pending"
+	KafkaJobStatusRunning   = "running"
+	KafkaJobStatusDone      = "done"
+	KafkaJobStatusCancelled = "cancelled"
+)
@@ -0,0 +1,263 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: kafka_job_progress.proto
+
+package models
+
+import (
+	context "context"
+	fmt "fmt"
+	
+	_ "github.com/infobloxopen/protoc-gen-gorm/options"
+	math "math"
+
+	gorm2 "github.com/infobloxopen/atlas-app-toolkit/gorm"
+	errors1 "github.com/infobloxopen/protoc-gen-gorm/errors"
+	gorm1 "github.com/jinzhu/gorm"
+	field_mask1 "google.golang.org/genproto/protobuf/field_mask"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type KafkaJobProgressORM struct {
+	ConsumedOffset   int64
+	JobId            string `gorm:"primary_key"`
+	Partition        uint64 `gorm:"primary_key"`
+	StartBlockNumber uint64
+	StartOffset      uint64
+	Status           string `gorm:"index:kafka_job_progress_idx_status"`
+	StopBlockNumber  uint64
+	StopOffset       uint64
+	Topic            string `gorm:"primary_key"`
+	UpdatedAt        int64
+	WorkerGroup      string `gorm:"primary_key"`
+}
+
+// TableName overrides the default tablename generated by GORM
+func (KafkaJobProgressORM) TableName() string {
+	return "kafka_job_progresses"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *KafkaJobProgress) ToORM(ctx context.Context) (KafkaJobProgressORM, error) {
+	to := KafkaJobProgressORM{}
+	var err error
+	if prehook, ok := interface{}(m).(KafkaJobProgressWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.JobId = m.JobId
+	to.WorkerGroup = m.WorkerGroup
+	to.Topic = m.Topic
+	to.Partition = m.Partition
+	to.StartOffset = m.StartOffset
+	to.StopOffset = m.StopOffset
+	to.StartBlockNumber = m.StartBlockNumber
+	to.StopBlockNumber = m.StopBlockNumber
+	to.ConsumedOffset = m.ConsumedOffset
+	to.Status = m.Status
+	to.UpdatedAt = m.UpdatedAt
+	if posthook, ok := interface{}(m).(KafkaJobProgressWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *KafkaJobProgressORM) ToPB(ctx context.Context) (KafkaJobProgress, error) {
+	to := KafkaJobProgress{}
+	var err error
+	if prehook, ok := interface{}(m).(KafkaJobProgressWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.JobId = m.JobId
+	to.WorkerGroup = m.WorkerGroup
+	to.Topic = m.Topic
+	to.Partition = m.Partition
+	to.StartOffset = m.StartOffset
+	to.StopOffset = m.StopOffset
+	to.StartBlockNumber = m.StartBlockNumber
+	to.StopBlockNumber = m.StopBlockNumber
+	to.ConsumedOffset = m.ConsumedOffset
+	to.Status = m.Status
+	to.UpdatedAt = m.UpdatedAt
+	if posthook, ok := interface{}(m).(KafkaJobProgressWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type KafkaJobProgress the arg will be the target, the caller the one being converted from
+
+// KafkaJobProgressBeforeToORM called before default ToORM code
+type KafkaJobProgressWithBeforeToORM interface {
+	BeforeToORM(context.Context, *KafkaJobProgressORM) error
+}
+
+// KafkaJobProgressAfterToORM called after default ToORM code
+type KafkaJobProgressWithAfterToORM interface {
+	AfterToORM(context.Context, *KafkaJobProgressORM) error
+}
+
+// KafkaJobProgressBeforeToPB called before default ToPB code
+type KafkaJobProgressWithBeforeToPB interface {
+	BeforeToPB(context.Context, *KafkaJobProgress) error
+}
+
+// KafkaJobProgressAfterToPB called after default ToPB code
+type KafkaJobProgressWithAfterToPB interface {
+	AfterToPB(context.Context, *KafkaJobProgress) error
+}
+
+// DefaultCreateKafkaJobProgress executes a basic gorm create call
+func DefaultCreateKafkaJobProgress(ctx context.Context, in *KafkaJobProgress, db *gorm1.DB) (*KafkaJobProgress, error) {
+	if in == nil {
+		return nil, errors1.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(KafkaJobProgressORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(KafkaJobProgressORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type KafkaJobProgressORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type KafkaJobProgressORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm1.DB) error
+}
+
+// DefaultApplyFieldMaskKafkaJobProgress patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskKafkaJobProgress(ctx context.Context, patchee *KafkaJobProgress, patcher *KafkaJobProgress, updateMask *field_mask1.FieldMask, prefix string, db *gorm1.DB) (*KafkaJobProgress, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors1.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"JobId" {
+			patchee.JobId = patcher.JobId
+			continue
+		}
+		if f == prefix+"WorkerGroup" {
+			patchee.WorkerGroup = patcher.WorkerGroup
+			continue
+		}
+		if f == prefix+"Topic" {
+			patchee.Topic = patcher.Topic
+			continue
+		}
+		if f == prefix+"Partition" {
+			patchee.Partition = patcher.Partition
+			continue
+		}
+		if f == prefix+"StartOffset" {
+			patchee.StartOffset = patcher.StartOffset
+			continue
+		}
+		if f == prefix+"StopOffset" {
+			patchee.StopOffset = patcher.StopOffset
+			continue
+		}
+		if f == prefix+"StartBlockNumber" {
+			patchee.StartBlockNumber = patcher.StartBlockNumber
+			continue
+		}
+		if f == prefix+"StopBlockNumber" {
+			patchee.StopBlockNumber = patcher.StopBlockNumber
+			continue
+		}
+		if f == prefix+"ConsumedOffset" {
+			patchee.ConsumedOffset = patcher.ConsumedOffset
+			continue
+		}
+		if f == prefix+"Status" {
+			patchee.Status = patcher.Status
+			continue
+		}
+		if f == prefix+"UpdatedAt" {
+			patchee.UpdatedAt = patcher.UpdatedAt
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListKafkaJobProgress executes a gorm list call
+func DefaultListKafkaJobProgress(ctx context.Context, db *gorm1.DB) ([]*KafkaJobProgress, error) {
+	in := KafkaJobProgress{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(KafkaJobProgressORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db, err = gorm2.ApplyCollectionOperators(ctx, db, &KafkaJobProgressORM{}, &KafkaJobProgress{}, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(KafkaJobProgressORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("job_id")
+	ormResponse := []KafkaJobProgressORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(KafkaJobProgressORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*KafkaJobProgress{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type KafkaJobProgressORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type KafkaJobProgressORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type KafkaJobProgressORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm1.DB, *[]KafkaJobProgressORM) error
+}
@@ -0,0 +1,52 @@
+package probes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/crud"
+)
+
+// CheckerFunc - health checker calling fn with the check timeout
+// NOTE fn runs in its own go routine so a check waiting on a connection still times out
+type CheckerFunc func(ctx context.Context) error
+
+// Status - run the check
+func (f CheckerFunc) Status() (interface{}, error) {
+	timeout := time.Duration(config.Config.HealthCheckTimeout) * time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- f(ctx)
+	}()
+
+	select {
+	case err := <-errChan:
+		return nil, err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("timed out after %s", timeout)
+	}
+}
+
+// Freshness - fails when the latest block in postgres is older than maxAge
+func Freshness(maxAge time.Duration) CheckerFunc {
+	return func(ctx context.Context) error {
+		block, err := crud.GetBlockModel().SelectOne(ctx, 0)
+		if err != nil {
+			return err
+		}
+
+		// NOTE block timestamps are in microseconds
+		age := time.Since(time.Unix(0, int64(block.Timestamp)*int64(time.Microsecond)))
+		if age > maxAge {
+			return fmt.Errorf("latest block %d is %s old, max %s", block.Number, age.Round(time.Second), maxAge)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,33 @@
+package probes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-blocks/config"
+)
+
+func TestCheckerFunc(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.HealthCheckTimeout = 1
+
+	// Error is returned
+	_, err := CheckerFunc(func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}).Status()
+	assert.EqualError(err, "connection refused")
+
+	// Blocked checks time out
+	block := make(chan bool)
+	defer close(block)
+
+	_, err = CheckerFunc(func(ctx context.Context) error {
+		<-block
+		return nil
+	}).Status()
+	assert.EqualError(err, "timed out after 1s")
+}
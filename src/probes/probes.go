@@ -0,0 +1,89 @@
+package probes
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/InVisionApp/go-health/v2"
+	"github.com/InVisionApp/go-health/v2/handlers"
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-blocks/config"
+)
+
+// Severity - probes failed by a failing check
+type Severity string
+
+const (
+	// SeverityLiveness - fails liveness and readiness
+	SeverityLiveness Severity = "liveness"
+
+	// SeverityReadiness - fails readiness
+	SeverityReadiness Severity = "readiness"
+
+	// SeverityInfo - reported, never fails a probe
+	SeverityInfo Severity = "info"
+)
+
+// Check - health check with its default severity
+// NOTE severities can be overridden with HEALTH_CHECK_SEVERITIES
+type Check struct {
+	Name     string
+	Checker  health.ICheckable
+	Severity Severity
+}
+
+// Start - poll checks and serve the health, liveness and readiness endpoints
+func Start(checks []Check) {
+	h := health.New()
+
+	severities := map[string]Severity{}
+	healthConfigs := make([]*health.Config, len(checks))
+	for i, check := range checks {
+		severity := severityFor(check)
+		severities[check.Name] = severity
+
+		healthConfigs[i] = &health.Config{
+			Name:     check.Name,
+			Checker:  check.Checker,
+			Interval: time.Duration(config.Config.HealthPollingInterval) * time.Second,
+			Fatal:    severity != SeverityInfo,
+		}
+	}
+
+	// Add the checks to the health instance
+	if err := h.AddChecks(healthConfigs); err != nil {
+		zap.S().Fatalf("Unable to add health checks: %v", err)
+	}
+
+	//  Start the healthcheck process
+	if err := h.Start(); err != nil {
+		zap.S().Fatalf("Unable to start healthcheck: %v", err)
+	}
+
+	// Every check
+	http.HandleFunc(config.Config.HealthPrefix, handlers.NewJSONHandlerFunc(h, nil))
+
+	// Probes
+	http.HandleFunc(config.Config.HealthLivenessPrefix, probeHandler(h, severities, SeverityLiveness))
+	http.HandleFunc(config.Config.HealthReadinessPrefix, probeHandler(h, severities, SeverityReadiness))
+
+	go http.ListenAndServe(":"+config.Config.HealthPort, nil)
+	zap.S().Info("Started Healthcheck:", config.Config.HealthPort)
+}
+
+// severityFor - configured severity of a check, or its default
+func severityFor(check Check) Severity {
+	configured, ok := config.Config.HealthCheckSeverities[check.Name]
+	if !ok {
+		return check.Severity
+	}
+
+	switch severity := Severity(configured); severity {
+	case SeverityLiveness, SeverityReadiness, SeverityInfo:
+		return severity
+	}
+
+	zap.S().Warn("Healthcheck: unknown severity ", configured, " for check ", check.Name, ", using ", check.Severity)
+	return check.Severity
+}
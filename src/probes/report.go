@@ -0,0 +1,105 @@
+package probes
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/InVisionApp/go-health/v2"
+)
+
+// Check statuses
+const (
+	statusOK      = "ok"
+	statusFailed  = "failed"
+	statusPending = "pending"
+)
+
+// probeReport - liveness or readiness response
+type probeReport struct {
+	Status string                  `json:"status"`
+	Checks map[string]*checkReport `json:"checks"`
+}
+
+// checkReport - state of one check in a probe response
+type checkReport struct {
+	Status             string    `json:"status"`
+	Severity           Severity  `json:"severity"`
+	Error              string    `json:"error,omitempty"`
+	CheckTime          time.Time `json:"check_time,omitempty"`
+	ContiguousFailures int64     `json:"contiguous_failures,omitempty"`
+}
+
+// newProbeReport - state of every check, failed if a check failing probe is not ok
+// NOTE checks that have not run yet fail readiness only
+func newProbeReport(states map[string]health.State, severities map[string]Severity, probe Severity) *probeReport {
+	report := &probeReport{
+		Status: statusOK,
+		Checks: make(map[string]*checkReport, len(severities)),
+	}
+
+	for name, severity := range severities {
+		check := &checkReport{
+			Status:   statusPending,
+			Severity: severity,
+		}
+
+		state, ok := states[name]
+		if ok {
+			check.Status = state.Status
+			check.Error = state.Err
+			check.CheckTime = state.CheckTime
+			check.ContiguousFailures = state.ContiguousFailures
+		}
+		report.Checks[name] = check
+
+		if check.Status == statusOK || !failsProbe(severity, probe) {
+			continue
+		}
+		if check.Status == statusPending && probe == SeverityLiveness {
+			continue
+		}
+
+		report.Status = statusFailed
+	}
+
+	return report
+}
+
+// failsProbe - true if a failing check of severity fails probe
+func failsProbe(severity Severity, probe Severity) bool {
+	switch probe {
+	case SeverityLiveness:
+		return severity == SeverityLiveness
+	case SeverityReadiness:
+		return severity == SeverityLiveness || severity == SeverityReadiness
+	}
+
+	return false
+}
+
+func probeHandler(h health.IHealth, severities map[string]Severity, probe Severity) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		states, _, err := h.State()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		report := newProbeReport(states, severities, probe)
+
+		body, err := json.Marshal(report)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if report.Status != statusOK {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+		w.Write(body)
+	}
+}
@@ -0,0 +1,74 @@
+package probes
+
+import (
+	"testing"
+
+	"github.com/InVisionApp/go-health/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-blocks/config"
+)
+
+func TestNewProbeReport(t *testing.T) {
+	assert := assert.New(t)
+
+	severities := map[string]Severity{
+		"api":       SeverityLiveness,
+		"postgres":  SeverityReadiness,
+		"freshness": SeverityInfo,
+	}
+
+	// Every check ok
+	states := map[string]health.State{
+		"api":       {Name: "api", Status: "ok"},
+		"postgres":  {Name: "postgres", Status: "ok"},
+		"freshness": {Name: "freshness", Status: "ok"},
+	}
+	assert.Equal("ok", newProbeReport(states, severities, SeverityLiveness).Status)
+	assert.Equal("ok", newProbeReport(states, severities, SeverityReadiness).Status)
+
+	// Info checks never fail a probe
+	states["freshness"] = health.State{Name: "freshness", Status: "failed", Err: "latest block is old"}
+	assert.Equal("ok", newProbeReport(states, severities, SeverityLiveness).Status)
+	assert.Equal("ok", newProbeReport(states, severities, SeverityReadiness).Status)
+
+	report := newProbeReport(states, severities, SeverityReadiness)
+	assert.Equal("failed", report.Checks["freshness"].Status)
+	assert.Equal("latest block is old", report.Checks["freshness"].Error)
+	assert.Equal(SeverityInfo, report.Checks["freshness"].Severity)
+
+	// Readiness checks fail readiness only
+	states["postgres"] = health.State{Name: "postgres", Status: "failed", ContiguousFailures: 3}
+	assert.Equal("ok", newProbeReport(states, severities, SeverityLiveness).Status)
+	assert.Equal("failed", newProbeReport(states, severities, SeverityReadiness).Status)
+
+	// Liveness checks fail both
+	states["postgres"] = health.State{Name: "postgres", Status: "ok"}
+	states["api"] = health.State{Name: "api", Status: "failed"}
+	assert.Equal("failed", newProbeReport(states, severities, SeverityLiveness).Status)
+	assert.Equal("failed", newProbeReport(states, severities, SeverityReadiness).Status)
+
+	// Checks not run yet fail readiness only
+	delete(states, "api")
+	assert.Equal("ok", newProbeReport(states, severities, SeverityLiveness).Status)
+	assert.Equal("failed", newProbeReport(states, severities, SeverityReadiness).Status)
+	assert.Equal("pending", newProbeReport(states, severities, SeverityReadiness).Checks["api"].Status)
+}
+
+func TestSeverityFor(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.HealthCheckSeverities = map[string]string{
+		"postgres": "info",
+		"redis":    "fatal",
+	}
+
+	// Default
+	assert.Equal(SeverityLiveness, severityFor(Check{Name: "api", Severity: SeverityLiveness}))
+
+	// Configured
+	assert.Equal(SeverityInfo, severityFor(Check{Name: "postgres", Severity: SeverityReadiness}))
+
+	// Unknown severities are ignored
+	assert.Equal(SeverityReadiness, severityFor(Check{Name: "redis", Severity: SeverityReadiness}))
+}
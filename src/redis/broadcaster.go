@@ -1,55 +1,138 @@
 package redis
 
 import (
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/metrics"
 )
 
 // BroadcasterID - type for broadcaster channel IDs
 type BroadcasterID uint64
 
-var lastBroadcasterID BroadcasterID = 0
+// SlowConsumerPolicy - action taken when a subscriber's buffer is full
+type SlowConsumerPolicy string
+
+const (
+	// SlowConsumerPolicyDrop - skip the message for the slow subscriber only
+	SlowConsumerPolicyDrop SlowConsumerPolicy = "drop"
+
+	// SlowConsumerPolicyDisconnect - remove the slow subscriber and close its channel
+	SlowConsumerPolicyDisconnect SlowConsumerPolicy = "disconnect"
+)
 
 // Broadcaster - Broadcaster channels
 type Broadcaster struct {
 	InputChannel chan []byte
 
 	// Output
-	OutputChannels map[BroadcasterID]chan []byte
+	shards     []*broadcasterShard
+	lastID     uint64 // NOTE accessed atomically
+	bufferSize int
+	policy     SlowConsumerPolicy
+}
+
+// broadcasterShard - subset of subscribers guarded by its own lock
+type broadcasterShard struct {
+	mutex    sync.RWMutex
+	channels map[BroadcasterID]chan []byte
 }
 
-var broadcaster *Broadcaster
-var broadcasterOnce sync.Once
+var broadcasters = map[string]*Broadcaster{}
+var broadcastersMutex sync.Mutex
 
+// GetBroadcaster - create and/or return the broadcaster for the blocks channel
 func GetBroadcaster() *Broadcaster {
-	broadcasterOnce.Do(func() {
-		broadcaster = &Broadcaster{
-			InputChannel:   make(chan []byte),
-			OutputChannels: make(map[BroadcasterID]chan []byte),
-		}
-	})
+	return GetChannelBroadcaster(config.Config.RedisChannel)
+}
+
+// GetChannelBroadcaster - create and/or return the broadcaster for a redis channel
+func GetChannelBroadcaster(channel string) *Broadcaster {
+	broadcastersMutex.Lock()
+	defer broadcastersMutex.Unlock()
+
+	broadcaster, ok := broadcasters[channel]
+	if !ok {
+		broadcaster = newBroadcaster(
+			config.Config.BroadcasterShards,
+			config.Config.BroadcasterBufferSize,
+			parseSlowConsumerPolicy(config.Config.BroadcasterSlowConsumerPolicy),
+		)
+		broadcasters[channel] = broadcaster
+	}
 
 	return broadcaster
 }
 
-// AddBroadcastChannel - add channel to  broadcaster
+func newBroadcaster(shardCount int, bufferSize int, policy SlowConsumerPolicy) *Broadcaster {
+	if shardCount < 1 {
+		shardCount = 1
+	}
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+
+	shards := make([]*broadcasterShard, shardCount)
+	for i := range shards {
+		shards[i] = &broadcasterShard{
+			channels: make(map[BroadcasterID]chan []byte),
+		}
+	}
+
+	return &Broadcaster{
+		InputChannel: make(chan []byte),
+		shards:       shards,
+		bufferSize:   bufferSize,
+		policy:       policy,
+	}
+}
+
+func parseSlowConsumerPolicy(policy string) SlowConsumerPolicy {
+	switch SlowConsumerPolicy(strings.ToLower(policy)) {
+	case SlowConsumerPolicyDisconnect:
+		return SlowConsumerPolicyDisconnect
+	case SlowConsumerPolicyDrop:
+		return SlowConsumerPolicyDrop
+	default:
+		zap.S().Warn("Broadcaster: unknown slow consumer policy '", policy, "', using '", SlowConsumerPolicyDrop, "'")
+		return SlowConsumerPolicyDrop
+	}
+}
+
+// NewBroadcastChannel - create a subscriber channel with the configured buffer size
+func (b *Broadcaster) NewBroadcastChannel() chan []byte {
+	return make(chan []byte, b.bufferSize)
+}
+
+// AddBroadcastChannel - add channel to broadcaster
+// NOTE the broadcaster closes the channel once it is removed
 func (b *Broadcaster) AddBroadcastChannel(channel chan []byte) BroadcasterID {
 
-	id := lastBroadcasterID
-	lastBroadcasterID++
+	id := BroadcasterID(atomic.AddUint64(&b.lastID, 1))
+
+	shard := b.getShard(id)
+	shard.mutex.Lock()
+	shard.channels[id] = channel
+	shard.mutex.Unlock()
 
-	b.OutputChannels[id] = channel
+	metrics.BroadcasterSubscribersGauge.Inc()
 
 	return id
 }
 
-// RemoveBroadcastChannelnel - remove channel from broadcaster
+// RemoveBroadcastChannel - remove channel from broadcaster
+// Safe to call more than once for the same ID
 func (b *Broadcaster) RemoveBroadcastChannel(id BroadcasterID) {
 
-	_, ok := b.OutputChannels[id]
-	if ok {
-		delete(b.OutputChannels, id)
-	}
+	shard := b.getShard(id)
+	shard.mutex.Lock()
+	shard.remove(id)
+	shard.mutex.Unlock()
 }
 
 // Start - Start broadcaster go routine
@@ -58,13 +141,79 @@ func (b *Broadcaster) Start() {
 		for {
 			msg := <-b.InputChannel
 
-			for id, channel := range b.OutputChannels {
-				select {
-				case channel <- msg:
-				case <-time.After(time.Second * 1):
-					b.RemoveBroadcastChannel(id)
-				}
-			}
+			b.broadcast(msg)
 		}
 	}()
 }
+
+func (b *Broadcaster) getShard(id BroadcasterID) *broadcasterShard {
+	return b.shards[uint64(id)%uint64(len(b.shards))]
+}
+
+// broadcast - fan msg out to every shard in parallel
+func (b *Broadcaster) broadcast(msg []byte) {
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for _, shard := range b.shards {
+		wg.Add(1)
+		go func(shard *broadcasterShard) {
+			defer wg.Done()
+
+			b.broadcastShard(shard, msg)
+		}(shard)
+	}
+	wg.Wait()
+
+	metrics.BroadcasterFanOutLatencyHistogram.Observe(time.Since(start).Seconds())
+}
+
+func (b *Broadcaster) broadcastShard(shard *broadcasterShard, msg []byte) {
+
+	// Never block on a subscriber
+	// NOTE sends happen under the read lock so a channel cannot be closed mid-send
+	slowIDs := []BroadcasterID{}
+	shard.mutex.RLock()
+	for id, channel := range shard.channels {
+		select {
+		case channel <- msg:
+		default:
+			metrics.BroadcasterDroppedMessagesCounter.Inc()
+
+			if b.policy == SlowConsumerPolicyDisconnect {
+				slowIDs = append(slowIDs, id)
+			}
+		}
+	}
+	shard.mutex.RUnlock()
+
+	if len(slowIDs) == 0 {
+		return
+	}
+
+	// Disconnect slow subscribers
+	shard.mutex.Lock()
+	for _, id := range slowIDs {
+		if shard.remove(id) {
+			metrics.BroadcasterDisconnectedSubscribersCounter.Inc()
+			zap.S().Info("Broadcaster: disconnected slow subscriber ID=", id)
+		}
+	}
+	shard.mutex.Unlock()
+}
+
+// remove - remove and close channel, returns false if already removed
+// NOTE caller must hold the write lock
+func (s *broadcasterShard) remove(id BroadcasterID) bool {
+	channel, ok := s.channels[id]
+	if !ok {
+		return false
+	}
+
+	delete(s.channels, id)
+	close(channel)
+
+	metrics.BroadcasterSubscribersGauge.Dec()
+
+	return true
+}
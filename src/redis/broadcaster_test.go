@@ -0,0 +1,144 @@
+package redis
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroadcasterFanOut(t *testing.T) {
+	assert := assert.New(t)
+
+	b := newBroadcaster(4, 8, SlowConsumerPolicyDrop)
+
+	channels := []chan []byte{}
+	for i := 0; i < 20; i++ {
+		channel := b.NewBroadcastChannel()
+		b.AddBroadcastChannel(channel)
+		channels = append(channels, channel)
+	}
+
+	b.broadcast([]byte("block"))
+
+	for _, channel := range channels {
+		assert.Equal([]byte("block"), <-channel)
+	}
+}
+
+func TestBroadcasterRemoveClosesChannel(t *testing.T) {
+	assert := assert.New(t)
+
+	b := newBroadcaster(4, 8, SlowConsumerPolicyDrop)
+
+	channel := b.NewBroadcastChannel()
+	id := b.AddBroadcastChannel(channel)
+
+	b.RemoveBroadcastChannel(id)
+	b.RemoveBroadcastChannel(id) // second remove is a no-op
+
+	_, ok := <-channel
+	assert.Equal(false, ok)
+}
+
+func TestBroadcasterSlowConsumerDrop(t *testing.T) {
+	assert := assert.New(t)
+
+	b := newBroadcaster(1, 1, SlowConsumerPolicyDrop)
+
+	slowChannel := b.NewBroadcastChannel()
+	b.AddBroadcastChannel(slowChannel)
+
+	fastChannel := b.NewBroadcastChannel()
+	b.AddBroadcastChannel(fastChannel)
+
+	for _, msg := range []string{"1", "2", "3"} {
+		b.broadcast([]byte(msg))
+		assert.Equal([]byte(msg), <-fastChannel)
+	}
+
+	// Slow subscriber keeps the first message only and stays subscribed
+	assert.Equal([]byte("1"), <-slowChannel)
+	b.broadcast([]byte("4"))
+	assert.Equal([]byte("4"), <-slowChannel)
+}
+
+func TestBroadcasterSlowConsumerDisconnect(t *testing.T) {
+	assert := assert.New(t)
+
+	b := newBroadcaster(1, 1, SlowConsumerPolicyDisconnect)
+
+	slowChannel := b.NewBroadcastChannel()
+	b.AddBroadcastChannel(slowChannel)
+
+	b.broadcast([]byte("1"))
+	b.broadcast([]byte("2"))
+
+	// Buffered message is still delivered, then the channel is closed
+	msg, ok := <-slowChannel
+	assert.Equal(true, ok)
+	assert.Equal([]byte("1"), msg)
+
+	_, ok = <-slowChannel
+	assert.Equal(false, ok)
+}
+
+func TestBroadcasterConcurrentSubscribers(t *testing.T) {
+	assert := assert.New(t)
+
+	b := newBroadcaster(8, 4, SlowConsumerPolicyDisconnect)
+	b.Start()
+
+	done := make(chan bool)
+
+	// Publisher
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case b.InputChannel <- []byte("block"):
+			}
+		}
+	}()
+
+	// Subscribers joining and leaving while messages are broadcast
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			channel := b.NewBroadcastChannel()
+			id := b.AddBroadcastChannel(channel)
+
+			timeout := time.After(100 * time.Millisecond)
+			for read := 0; read < 10; read++ {
+				select {
+				case _, ok := <-channel:
+					if !ok {
+						return
+					}
+				case <-timeout:
+					read = 10
+				}
+
+				if i%2 == 0 {
+					// Slow reader
+					time.Sleep(time.Millisecond)
+				}
+			}
+
+			b.RemoveBroadcastChannel(id)
+		}(i)
+	}
+	wg.Wait()
+	close(done)
+
+	for _, shard := range b.shards {
+		shard.mutex.RLock()
+		assert.Equal(0, len(shard.channels))
+		shard.mutex.RUnlock()
+	}
+}
@@ -21,6 +21,16 @@ type Client struct {
 var redisClient *Client
 var redisClientOnce sync.Once
 
+// Channels - all redis pubsub channels used for live streams
+func Channels() []string {
+	return []string{
+		config.Config.RedisChannel,
+		config.Config.RedisChannelBlockTransactions,
+		config.Config.RedisChannelBlockInternalTransactions,
+		config.Config.RedisChannelBlockFailedTransactions,
+	}
+}
+
 func GetRedisClient() *Client {
 	redisClientOnce.Do(func() {
 		addr := config.Config.RedisHost + ":" + config.Config.RedisPort
@@ -60,7 +70,7 @@ func GetRedisClient() *Client {
 			}
 
 			// Init pubsub
-			redisClient.pubsub = redisClient.client.Subscribe(ctx, config.Config.RedisChannel)
+			redisClient.pubsub = redisClient.client.Subscribe(ctx, Channels()...)
 
 			// Test pubsub
 			_, err = redisClient.pubsub.Receive(ctx)
@@ -80,3 +90,13 @@ func GetRedisClient() *Client {
 
 	return redisClient
 }
+
+// Ping - check the redis connection
+func (c *Client) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
+// PingSubscription - check the pubsub connection feeding the broadcasters
+func (c *Client) PingSubscription(ctx context.Context) error {
+	return c.pubsub.Ping(ctx)
+}
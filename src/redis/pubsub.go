@@ -4,20 +4,39 @@ import (
 	"context"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
-	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/tracing"
 )
 
-func (c *Client) Publish(data []byte) {
-	for {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
+// Publish - publish data to a redis channel, retrying until success
+func (c *Client) Publish(ctx context.Context, channel string, data []byte) {
+	ctx, span := tracing.Start(
+		ctx,
+		"redis.publish "+channel,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("db.system", "redis"),
+			attribute.String("messaging.destination", channel),
+		),
+	)
+	defer span.End()
+
+	for attempt := 1; ; attempt++ {
+		publishCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 
 		// Publish
-		err := c.client.Publish(ctx, config.Config.RedisChannel, string(data)).Err()
-		if err != nil {
+		err := c.client.Publish(publishCtx, channel, string(data)).Err()
+		cancel()
+		if err != nil && ctx.Err() != nil {
+			// Shutting down
+			zap.S().Info("Redis Publish: stopped, message not published: ", ctx.Err().Error())
+			return
+		} else if err != nil {
 			// Failure
+			span.AddEvent("retry", trace.WithAttributes(attribute.Int("attempt", attempt)))
 			zap.S().Warn("Redis Publish: Cannot publish message...retrying in 3 second")
 			time.Sleep(3 * time.Second)
 
@@ -29,16 +48,16 @@ func (c *Client) Publish(data []byte) {
 	}
 }
 
+// StartSubscriber - forward messages from all channels to their broadcasters
 func (c *Client) StartSubscriber() {
 
 	go func() {
 		subscriberChannel := c.pubsub.Channel()
-		inputChannel := GetBroadcaster().InputChannel
 
 		for {
 			redisMsg := <-subscriberChannel
 
-			inputChannel <- []byte(redisMsg.Payload)
+			GetChannelBroadcaster(redisMsg.Channel).InputChannel <- []byte(redisMsg.Payload)
 		}
 	}()
 }
@@ -0,0 +1,89 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// tokenBucketScript - refill a bucket and take one token atomically
+// KEYS[1] bucket key
+// ARGV[1] capacity, ARGV[2] tokens per second, ARGV[3] now in milliseconds
+// Returns: allowed (0 or 1), tokens left, milliseconds until the next token, milliseconds until full
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+  tokens = capacity
+  ts = now
+end
+
+tokens = math.min(capacity, tokens + math.max(0, now - ts) / 1000 * rate)
+
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", tostring(now))
+redis.call("PEXPIRE", KEYS[1], math.ceil(capacity / rate * 1000))
+
+local wait = 0
+if tokens < 1 then
+  wait = math.ceil((1 - tokens) / rate * 1000)
+end
+
+local full = math.ceil((capacity - tokens) / rate * 1000)
+
+return {allowed, math.floor(tokens), wait, full}
+`)
+
+// TokenBucketResult - outcome of taking a token
+type TokenBucketResult struct {
+	Allowed   bool
+	Remaining int64
+	// Time until the next token is available
+	RetryAfter time.Duration
+	// Time until the bucket is full again
+	Reset time.Duration
+}
+
+// TakeToken - take one token from the bucket at key
+// NOTE buckets are shared by every API replica using the same redis
+func (c *Client) TakeToken(ctx context.Context, key string, capacity int, perSecond float64) (*TokenBucketResult, error) {
+
+	result, err := tokenBucketScript.Run(
+		ctx,
+		c.client,
+		[]string{key},
+		capacity,
+		perSecond,
+		time.Now().UnixNano()/int64(time.Millisecond),
+	).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	// Lua numbers are returned as integers
+	values := make([]int64, 4)
+	results, _ := result.([]interface{})
+	for i := range values {
+		if i < len(results) {
+			values[i], _ = results[i].(int64)
+		}
+	}
+
+	return &TokenBucketResult{
+		Allowed:    values[0] == 1,
+		Remaining:  values[1],
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+		Reset:      time.Duration(values[3]) * time.Millisecond,
+	}, nil
+}
@@ -6,7 +6,7 @@ export GOPATH=$HOME/go
 export GOBIN=$GOPATH/bin
 export PATH=$PATH:$GOROOT:$GOPATH:$GOBIN
 
-protoc -I=. -I=$GOPATH/src --go_out=.. --gorm_out=engine=postgres:.. *.proto
+protoc -I=. -I=$GOPATH/src --go_out=.. --go-grpc_out=.. --gorm_out=engine=postgres:.. *.proto
 
 # Remove omitempty option
 # Credit: https://stackoverflow.com/a/37335452
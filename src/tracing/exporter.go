@@ -0,0 +1,98 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// jsonSpan - one line written by the json exporter
+type jsonSpan struct {
+	TraceID       string                 `json:"trace_id"`
+	SpanID        string                 `json:"span_id"`
+	ParentSpanID  string                 `json:"parent_span_id,omitempty"`
+	Name          string                 `json:"name"`
+	Kind          string                 `json:"kind"`
+	Start         time.Time              `json:"start"`
+	End           time.Time              `json:"end"`
+	DurationMilli float64                `json:"duration_ms"`
+	Status        string                 `json:"status"`
+	StatusMessage string                 `json:"status_message,omitempty"`
+	Attributes    map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// jsonExporter - writes finished spans as json lines
+// Used for the stdout and file exporters when running locally
+type jsonExporter struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+}
+
+// newJSONExporter - closer is closed on shutdown, nil for stdout
+func newJSONExporter(writer io.Writer, closer io.Closer) *jsonExporter {
+	return &jsonExporter{
+		encoder: json.NewEncoder(writer),
+		closer:  closer,
+	}
+}
+
+// ExportSpans - write a batch of spans
+func (e *jsonExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for _, span := range spans {
+		err := e.encoder.Encode(toJSONSpan(span))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Shutdown - close the underlying file
+func (e *jsonExporter) Shutdown(ctx context.Context) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.closer == nil {
+		return nil
+	}
+
+	err := e.closer.Close()
+	e.closer = nil
+	return err
+}
+
+func toJSONSpan(span sdktrace.ReadOnlySpan) *jsonSpan {
+	line := &jsonSpan{
+		TraceID:       span.SpanContext().TraceID().String(),
+		SpanID:        span.SpanContext().SpanID().String(),
+		Name:          span.Name(),
+		Kind:          span.SpanKind().String(),
+		Start:         span.StartTime(),
+		End:           span.EndTime(),
+		DurationMilli: float64(span.EndTime().Sub(span.StartTime())) / float64(time.Millisecond),
+		Status:        span.Status().Code.String(),
+		StatusMessage: span.Status().Description,
+	}
+
+	if span.Parent().IsValid() {
+		line.ParentSpanID = span.Parent().SpanID().String()
+	}
+
+	if attributes := span.Attributes(); len(attributes) > 0 {
+		line.Attributes = make(map[string]interface{}, len(attributes))
+		for _, attribute := range attributes {
+			line.Attributes[string(attribute.Key)] = attribute.Value.AsInterface()
+		}
+	}
+
+	return line
+}
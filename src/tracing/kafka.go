@@ -0,0 +1,62 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/otel"
+)
+
+// kafkaHeaderCarrier - propagation carrier over kafka message headers
+type kafkaHeaderCarrier struct {
+	msg *sarama.ConsumerMessage
+}
+
+// Get - value of a header, empty if missing
+func (c kafkaHeaderCarrier) Get(key string) string {
+	for _, header := range c.msg.Headers {
+		if header != nil && string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+
+	return ""
+}
+
+// Set - replace or add a header
+func (c kafkaHeaderCarrier) Set(key string, value string) {
+	for _, header := range c.msg.Headers {
+		if header != nil && string(header.Key) == key {
+			header.Value = []byte(value)
+			return
+		}
+	}
+
+	c.msg.Headers = append(c.msg.Headers, &sarama.RecordHeader{
+		Key:   []byte(key),
+		Value: []byte(value),
+	})
+}
+
+// Keys - all header keys
+func (c kafkaHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.msg.Headers))
+	for _, header := range c.msg.Headers {
+		if header != nil {
+			keys = append(keys, string(header.Key))
+		}
+	}
+
+	return keys
+}
+
+// ExtractKafkaHeaders - context carrying the trace context in the message headers, if present
+func ExtractKafkaHeaders(ctx context.Context, msg *sarama.ConsumerMessage) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, kafkaHeaderCarrier{msg})
+}
+
+// InjectKafkaHeaders - write the trace context of ctx to the message headers
+// NOTE lets stages reading the message from a channel continue the trace
+func InjectKafkaHeaders(ctx context.Context, msg *sarama.ConsumerMessage) {
+	otel.GetTextMapPropagator().Inject(ctx, kafkaHeaderCarrier{msg})
+}
@@ -0,0 +1,118 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-blocks/config"
+)
+
+const tracerName = "github.com/geometry-labs/icon-blocks"
+
+var provider *sdktrace.TracerProvider
+
+// Init - set the global tracer provider and propagator from config
+// NOTE spans are no-ops when the exporter is none
+func Init() {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporter, err := newExporter(config.Config.TracingExporter)
+	if err != nil {
+		zap.S().Fatal("Tracing: unable to create exporter: ", err.Error())
+	}
+	if exporter == nil {
+		zap.S().Info("Tracing: disabled")
+		return
+	}
+
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.Config.TracingSampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			"",
+			attribute.String("service.name", config.Config.Name),
+			attribute.String("network_name", config.Config.NetworkName),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+
+	zap.S().Info("Tracing: exporting spans to ", config.Config.TracingExporter)
+}
+
+// Shutdown - flush buffered spans and stop the exporter
+func Shutdown() {
+	if provider == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := provider.Shutdown(ctx)
+	if err != nil {
+		zap.S().Warn("Tracing: shutdown error: ", err.Error())
+	}
+}
+
+func newExporter(name string) (sdktrace.SpanExporter, error) {
+	switch strings.ToLower(name) {
+	case "", "none":
+		return nil, nil
+	case "otlp":
+		options := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(config.Config.TracingOTLPEndpoint),
+		}
+		if config.Config.TracingOTLPInsecure == true {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+
+		return otlptracegrpc.New(context.Background(), options...)
+	case "stdout":
+		return newJSONExporter(os.Stdout, nil), nil
+	case "file":
+		file, err := os.OpenFile(config.Config.TracingFileName, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, err
+		}
+
+		return newJSONExporter(file, file), nil
+	}
+
+	return nil, fmt.Errorf("unknown exporter %q", name)
+}
+
+// Start - start a span from the global tracer
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, opts...)
+}
+
+// End - end a span, marking it failed when err is set
+// Returns err so calls can be chained into a return
+// NOTE record not found is an expected result, not a failure
+func End(span trace.Span, err error) error {
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+
+	return err
+}
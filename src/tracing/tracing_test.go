@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestKafkaHeaders(t *testing.T) {
+	assert := assert.New(t)
+
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	provider := sdktrace.NewTracerProvider()
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "produce")
+	span.End()
+
+	// Message without headers has no trace context
+	msg := &sarama.ConsumerMessage{}
+	assert.Equal(false, trace.SpanContextFromContext(ExtractKafkaHeaders(context.Background(), msg)).IsValid())
+
+	// Injected trace context is extracted
+	InjectKafkaHeaders(ctx, msg)
+	assert.Equal(1, len(msg.Headers))
+	assert.Equal("traceparent", string(msg.Headers[0].Key))
+
+	extracted := trace.SpanContextFromContext(ExtractKafkaHeaders(context.Background(), msg))
+	assert.Equal(span.SpanContext().TraceID(), extracted.TraceID())
+	assert.Equal(span.SpanContext().SpanID(), extracted.SpanID())
+
+	// Existing header is replaced
+	ctx, span = provider.Tracer("test").Start(ctx, "consume")
+	span.End()
+
+	InjectKafkaHeaders(ctx, msg)
+	assert.Equal(1, len(msg.Headers))
+
+	extracted = trace.SpanContextFromContext(ExtractKafkaHeaders(context.Background(), msg))
+	assert.Equal(span.SpanContext().SpanID(), extracted.SpanID())
+}
+
+func TestJSONExporter(t *testing.T) {
+	assert := assert.New(t)
+
+	buffer := &bytes.Buffer{}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(newJSONExporter(buffer, nil)))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	_, child := provider.Tracer("test").Start(ctx, "child", trace.WithAttributes(attribute.Int64("block.number", 10)))
+	child.End()
+	parent.End()
+
+	decoder := json.NewDecoder(buffer)
+
+	childLine := &jsonSpan{}
+	assert.Equal(nil, decoder.Decode(childLine))
+	assert.Equal("child", childLine.Name)
+	assert.Equal(parent.SpanContext().SpanID().String(), childLine.ParentSpanID)
+	assert.Equal(float64(10), childLine.Attributes["block.number"])
+
+	parentLine := &jsonSpan{}
+	assert.Equal(nil, decoder.Decode(parentLine))
+	assert.Equal("parent", parentLine.Name)
+	assert.Equal(childLine.TraceID, parentLine.TraceID)
+	assert.Equal("", parentLine.ParentSpanID)
+}
@@ -1,14 +1,19 @@
 package builders
 
 import (
+	"context"
 	"errors"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
 	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/global"
 	"github.com/geometry-labs/icon-blocks/models"
+	"github.com/geometry-labs/icon-blocks/tracing"
 )
 
 // Table builder for block_times
@@ -16,30 +21,40 @@ import (
 func StartBlockTimeBuilder() {
 
 	// Tail builder
-	go startBlockTimeBuilder(1, 2)
+	go startBlockTimeBuilder(global.ShutdownContext(), 1, 2)
 
 	// Head builder
-	// go startBlockTimeBuilder(1, 2)
+	// go startBlockTimeBuilder(global.ShutdownContext(), 1, 2)
 }
 
-func startBlockTimeBuilder(startParentBlockNumber uint32, startChildBlockNumber uint32) {
+func startBlockTimeBuilder(ctx context.Context, startParentBlockNumber uint32, startChildBlockNumber uint32) {
 
 	parentBlockNumber := startParentBlockNumber
 	childBlockNumber := startChildBlockNumber
 
+	position := newBuilderPosition("BlockTimeBuilder")
 	for {
+		iterationCtx, span := tracing.Start(
+			ctx,
+			"builder.BlockTimeBuilder",
+			trace.WithAttributes(
+				attribute.Int64("block.number", int64(childBlockNumber)),
+			),
+		)
+		position.set(iterationCtx, childBlockNumber)
 
 		////////////////////////
 		// Get blocks from DB //
 		////////////////////////
 
 		// Parent block
-		parentBlock, err := crud.GetBlockModel().SelectOne(parentBlockNumber)
+		parentBlock, err := crud.GetBlockModel().SelectOne(iterationCtx, parentBlockNumber)
 		if errors.Is(err, gorm.ErrRecordNotFound) || parentBlock.Hash == "" {
 			// Block does not exist yet
 			// Sleep and try again
 			zap.S().Info("Builder=BlockTimeBuilder, BlockNumber=", parentBlockNumber, " - Block not seen yet. Sleeping 3 seconds...")
 
+			span.End()
 			time.Sleep(3 * time.Second)
 			continue
 		} else if err != nil {
@@ -48,12 +63,13 @@ func startBlockTimeBuilder(startParentBlockNumber uint32, startChildBlockNumber
 		}
 
 		// Child block
-		childBlock, err := crud.GetBlockModel().SelectOne(childBlockNumber)
+		childBlock, err := crud.GetBlockModel().SelectOne(iterationCtx, childBlockNumber)
 		if errors.Is(err, gorm.ErrRecordNotFound) || childBlock.Timestamp == 0 {
 			// Block does not exist yet
 			// Sleep and try again
 			zap.S().Info("Builder=BlockTimeBuilder, BlockNumber=", childBlockNumber, " - Block not seen yet. Sleeping 3 seconds...")
 
+			span.End()
 			time.Sleep(3 * time.Second)
 			continue
 		} else if err != nil {
@@ -69,6 +85,7 @@ func startBlockTimeBuilder(startParentBlockNumber uint32, startChildBlockNumber
 		////////////////////////////
 		// Load to block_times DB //
 		////////////////////////////
+		global.AddInFlight()
 		crud.GetBlockTimeModel().LoaderChannel <- &models.BlockTime{
 			Number: childBlockNumber,
 			Time:   blockTime,
@@ -79,5 +96,7 @@ func startBlockTimeBuilder(startParentBlockNumber uint32, startChildBlockNumber
 		///////////////
 		parentBlockNumber++
 		childBlockNumber++
+
+		span.End()
 	}
 }
@@ -1,32 +1,45 @@
 package builders
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
 	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/global"
 	"github.com/geometry-labs/icon-blocks/models"
 	"github.com/geometry-labs/icon-blocks/redis"
+	"github.com/geometry-labs/icon-blocks/tracing"
 )
 
+// BlockTransactionBuilderCounters - suffixes of the redis counters, one per builder go routine
+var BlockTransactionBuilderCounters = []string{"_tail", "_head_v1"}
+
+// BlockTransactionBuilderCountKey - redis key of the next block number a builder will compute
+func BlockTransactionBuilderCountKey(redisCounterSuffix string) string {
+	return "icon_blocks_block_transaction_builder_start_number" + redisCounterSuffix
+}
+
 // Table builder for block_times
 // Builds table 'block_times' from 'blocks'
 func StartBlockTransactionBuilder() {
 
-	go startBlockTransactionBuilder(1, "_tail")
+	go startBlockTransactionBuilder(global.ShutdownContext(), 1, "_tail")
 
-	go startBlockTransactionBuilder(45669090, "_head_v1")
+	go startBlockTransactionBuilder(global.ShutdownContext(), 45669090, "_head_v1")
 }
 
-func startBlockTransactionBuilder(startBlockNumber int64, redisCounterSuffix string) {
+func startBlockTransactionBuilder(ctx context.Context, startBlockNumber int64, redisCounterSuffix string) {
 
 	// Query Redis for start block number
-	countKey := "icon_blocks_block_transaction_builder_start_number" + redisCounterSuffix
+	countKey := BlockTransactionBuilderCountKey(redisCounterSuffix)
 	blockNumberRedis, err := redis.GetRedisClient().GetCount(countKey)
 	if err != nil {
 		zap.S().Fatal("Builder=BlockTransactionBuilder, Error: ", err.Error())
@@ -36,14 +49,25 @@ func startBlockTransactionBuilder(startBlockNumber int64, redisCounterSuffix str
 	}
 
 	blockNumber := uint32(blockNumberRedis)
+	position := newBuilderPosition("BlockTransactionBuilder" + redisCounterSuffix)
 	for {
+		iterationCtx, span := tracing.Start(
+			ctx,
+			"builder.BlockTransactionBuilder",
+			trace.WithAttributes(
+				attribute.Int64("block.number", int64(blockNumber)),
+				attribute.String("builder.counter", redisCounterSuffix),
+			),
+		)
+		position.set(iterationCtx, blockNumber)
+
 		zap.S().Debug("Builder=BlockTransactionBuilder, BlockNumber=", blockNumber, " - Computing...")
 
 		//////////////
 		// Query DB //
 		//////////////
 
-		block, err := crud.GetBlockModel().SelectOne(blockNumber)
+		block, err := crud.GetBlockModel().SelectOne(iterationCtx, blockNumber)
 		if errors.Is(err, gorm.ErrRecordNotFound) || block.Hash == "" {
 			// Block does not exist yet
 			if redisCounterSuffix == "_head_v1" {
@@ -51,17 +75,19 @@ func startBlockTransactionBuilder(startBlockNumber int64, redisCounterSuffix str
 				// Move on if block is old
 
 				// If err, continue to sleep
-				latestBlock, err := crud.GetBlockModel().SelectOne(0)
+				latestBlock, err := crud.GetBlockModel().SelectOne(iterationCtx, 0)
 				if err != nil {
 					// Sleep and try again
 					zap.S().Info("Builder=BlockTransactionBuilder, BlockNumber=", blockNumber, " - Block not seen yet. Sleeping 1 second...")
 
+					span.End()
 					time.Sleep(1 * time.Second)
 					continue
 				}
 
 				if latestBlock.Number > blockNumber {
 					blockNumber++
+					span.End()
 					continue
 				}
 			}
@@ -69,6 +95,7 @@ func startBlockTransactionBuilder(startBlockNumber int64, redisCounterSuffix str
 			// Sleep and try again
 			zap.S().Info("Builder=BlockTransactionBuilder, BlockNumber=", blockNumber, " - Block not seen yet. Sleeping 1 second...")
 
+			span.End()
 			time.Sleep(1 * time.Second)
 			continue
 		} else if err != nil {
@@ -76,7 +103,7 @@ func startBlockTransactionBuilder(startBlockNumber int64, redisCounterSuffix str
 			zap.S().Fatal(err.Error())
 		}
 
-		transactions, err := crud.GetBlockTransactionModel().SelectMany(blockNumber)
+		transactions, err := crud.GetBlockTransactionModel().SelectMany(iterationCtx, blockNumber)
 		if errors.Is(err, gorm.ErrRecordNotFound) || len(*transactions) != int(block.TransactionCount) {
 			// Transacitons do not exist yet
 			if redisCounterSuffix == "_head_v1" {
@@ -84,17 +111,19 @@ func startBlockTransactionBuilder(startBlockNumber int64, redisCounterSuffix str
 				// Move on if block is old
 
 				// If err, continue to sleep
-				latestBlock, err := crud.GetBlockModel().SelectOne(0)
+				latestBlock, err := crud.GetBlockModel().SelectOne(iterationCtx, 0)
 				if err != nil {
 					// Sleep and try again
 					zap.S().Info("Builder=BlockTransactionBuilder, BlockNumber=", blockNumber, " - Block not seen yet. Sleeping 1 second...")
 
+					span.End()
 					time.Sleep(1 * time.Second)
 					continue
 				}
 
 				if latestBlock.Number > blockNumber {
 					blockNumber++
+					span.End()
 					continue
 				}
 			}
@@ -102,6 +131,7 @@ func startBlockTransactionBuilder(startBlockNumber int64, redisCounterSuffix str
 			// Sleep and try again
 			zap.S().Info("Builder=BlockTransactionBuilder, BlockNumber=", blockNumber, " - Transactions not seen yet. Sleeping 1 second...")
 
+			span.End()
 			time.Sleep(1 * time.Second)
 			continue
 		} else if err != nil {
@@ -136,6 +166,7 @@ func startBlockTransactionBuilder(startBlockNumber int64, redisCounterSuffix str
 		/////////////
 		// Load DB //
 		/////////////
+		global.AddInFlight()
 		crud.GetBlockModel().LoaderChannel <- &models.Block{
 			Number:            block.Number,
 			TransactionAmount: blockTransactionAmount,
@@ -157,5 +188,7 @@ func startBlockTransactionBuilder(startBlockNumber int64, redisCounterSuffix str
 			// Redis error
 			zap.S().Fatal("Error: ", err.Error())
 		}
+
+		span.End()
 	}
 }
@@ -0,0 +1,198 @@
+package builders
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/global"
+	"github.com/geometry-labs/icon-blocks/kafka"
+	"github.com/geometry-labs/icon-blocks/models"
+	"github.com/geometry-labs/icon-blocks/redis"
+	"github.com/geometry-labs/icon-blocks/tracing"
+)
+
+// EnrichedBlockProducerCountKey - redis key of the next block number the producer will publish
+const EnrichedBlockProducerCountKey = "icon_blocks_enriched_block_producer_start_number"
+
+// LogsHeadCountKey - redis key of the highest block number seen by the logs transformer
+const LogsHeadCountKey = "icon_blocks_logs_head_block_number"
+
+// Producer for enriched blocks
+// Publishes 'blocks' rows to PRODUCER_TOPICS once every enrichment is loaded
+// NOTE runs PRODUCER_LOGS_LAG blocks behind the logs head so internal transactions are loaded
+// NOTE disabled when PRODUCER_TOPICS is empty
+func StartEnrichedBlockProducer() {
+	if len(config.Config.ProducerTopics) == 0 {
+		zap.S().Info("Builder=EnrichedBlockProducer - PRODUCER_TOPICS not set, producer disabled")
+		return
+	}
+
+	producer, err := kafka.NewBlockProducer()
+	if err != nil {
+		zap.S().Fatal("Builder=EnrichedBlockProducer, Error: ", err.Error())
+	}
+
+	go startEnrichedBlockProducer(global.ShutdownContext(), producer)
+}
+
+func startEnrichedBlockProducer(ctx context.Context, producer *kafka.BlockProducer) {
+	defer producer.Close()
+
+	// Query Redis for start block number
+	// NOTE starts at the latest block the first time, earlier blocks were never published
+	blockNumberRedis, err := redis.GetRedisClient().GetCount(EnrichedBlockProducerCountKey)
+	if err != nil {
+		zap.S().Fatal("Builder=EnrichedBlockProducer, Error: ", err.Error())
+	} else if blockNumberRedis == -1 {
+		// No blockNumber set yet
+		blockNumberRedis = 1
+
+		latestBlock, err := crud.GetBlockModel().SelectOne(ctx, 0)
+		if err == nil {
+			blockNumberRedis = int64(latestBlock.Number)
+		}
+	}
+
+	blockNumber := uint32(blockNumberRedis)
+	position := newBuilderPosition("EnrichedBlockProducer")
+	for {
+		iterationCtx, span := tracing.Start(
+			ctx,
+			"builder.EnrichedBlockProducer",
+			trace.WithAttributes(
+				attribute.Int64("block.number", int64(blockNumber)),
+			),
+		)
+		position.set(iterationCtx, blockNumber)
+
+		//////////////
+		// Query DB //
+		//////////////
+
+		block, err := crud.GetBlockModel().SelectOne(iterationCtx, blockNumber)
+		if errors.Is(err, gorm.ErrRecordNotFound) || block.Hash == "" {
+			// Block does not exist yet
+			zap.S().Debug("Builder=EnrichedBlockProducer, BlockNumber=", blockNumber, " - Block not seen yet. Sleeping 1 second...")
+
+			span.End()
+			if sleepOrDone(ctx, 1*time.Second) {
+				return
+			}
+			continue
+		} else if ctx.Err() != nil {
+			// Shutdown
+			span.End()
+			return
+		} else if err != nil {
+			// Postgres error
+			zap.S().Fatal(err.Error())
+		}
+
+		transactions, err := crud.GetBlockTransactionModel().SelectMany(iterationCtx, blockNumber)
+		if ctx.Err() != nil {
+			// Shutdown
+			span.End()
+			return
+		} else if err != nil {
+			// Postgres error
+			zap.S().Fatal(err.Error())
+		}
+
+		logsHead, err := redis.GetRedisClient().GetCount(LogsHeadCountKey)
+		if err != nil {
+			// Redis error
+			zap.S().Fatal("Error: ", err.Error())
+		}
+
+		if isBlockEnriched(block, len(*transactions), logsHead) == false {
+			// Enrichments not loaded yet
+			zap.S().Debug("Builder=EnrichedBlockProducer, BlockNumber=", blockNumber, " - Block not enriched yet. Sleeping 1 second...")
+
+			span.End()
+			if sleepOrDone(ctx, 1*time.Second) {
+				return
+			}
+			continue
+		}
+
+		/////////////
+		// Produce //
+		/////////////
+		err = producer.Produce(block)
+		if err != nil {
+			// Kafka error
+			zap.S().Warn("Builder=EnrichedBlockProducer, BlockNumber=", blockNumber, " - Error: ", err.Error(), " - Retrying...")
+
+			span.End()
+			if sleepOrDone(ctx, 1*time.Second) {
+				return
+			}
+			continue
+		}
+
+		///////////////
+		// Increment //
+		///////////////
+		blockNumber++
+
+		if blockNumber%100000 == 0 {
+			zap.S().Info("Builder=EnrichedBlockProducer, BlockNumber=", blockNumber, " - Published 100,000 blocks")
+		}
+
+		// Set count in redis
+		err = redis.GetRedisClient().SetCount(EnrichedBlockProducerCountKey, int64(blockNumber))
+		if err != nil {
+			// Redis error
+			zap.S().Fatal("Error: ", err.Error())
+		}
+
+		span.End()
+	}
+}
+
+// isBlockEnriched - true once the block row has its transaction totals, block time and internal transactions
+// NOTE internal transactions come from logs with no expected count,
+// they are assumed loaded once the logs head is PRODUCER_LOGS_LAG blocks past the block
+func isBlockEnriched(block *models.Block, transactionCount int, logsHead int64) bool {
+	// Base
+	if block.Hash == "" {
+		return false
+	}
+
+	// block_transactions
+	if transactionCount != int(block.TransactionCount) || block.TransactionFees == "" || block.TransactionAmount == "" {
+		return false
+	}
+
+	// block_times
+	// NOTE the first block has no parent and no block time
+	if block.BlockTime == 0 && block.Number > 1 {
+		return false
+	}
+
+	// block_internal_transactions
+	if int64(block.Number)+int64(config.Config.ProducerLogsLag) > logsHead {
+		return false
+	}
+
+	return true
+}
+
+// sleepOrDone - sleep for duration
+// Returns: true if ctx was cancelled first
+func sleepOrDone(ctx context.Context, duration time.Duration) bool {
+	select {
+	case <-time.After(duration):
+		return false
+	case <-ctx.Done():
+		return true
+	}
+}
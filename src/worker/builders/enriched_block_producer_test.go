@@ -0,0 +1,53 @@
+package builders
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/models"
+)
+
+func TestIsBlockEnriched(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.ProducerLogsLag = 10
+
+	// enrichedBlock - block 100 with two transactions
+	enrichedBlock := func() *models.Block {
+		return &models.Block{
+			Number:            100,
+			Hash:              "0x100",
+			TransactionCount:  2,
+			TransactionFees:   "0x1",
+			TransactionAmount: "0x0",
+			BlockTime:         2000000,
+		}
+	}
+
+	testCases := []struct {
+		name             string
+		block            func(block *models.Block)
+		transactionCount int
+		logsHead         int64
+		enriched         bool
+	}{
+		{"enriched", func(block *models.Block) {}, 2, 200, true},
+		{"no hash", func(block *models.Block) { block.Hash = "" }, 2, 200, false},
+		{"transaction count mismatch", func(block *models.Block) {}, 1, 200, false},
+		{"empty transaction fees", func(block *models.Block) { block.TransactionFees = "" }, 2, 200, false},
+		{"empty transaction amount", func(block *models.Block) { block.TransactionAmount = "" }, 2, 200, false},
+		{"no block time", func(block *models.Block) { block.BlockTime = 0 }, 2, 200, false},
+		{"first block without block time", func(block *models.Block) { block.Number = 1; block.BlockTime = 0 }, 2, 200, true},
+		{"logs head at the lag", func(block *models.Block) {}, 2, 110, true},
+		{"logs head below the lag", func(block *models.Block) {}, 2, 109, false},
+	}
+
+	for _, testCase := range testCases {
+		block := enrichedBlock()
+		testCase.block(block)
+
+		assert.Equal(testCase.enriched, isBlockEnriched(block, testCase.transactionCount, testCase.logsHead), testCase.name)
+	}
+}
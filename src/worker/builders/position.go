@@ -0,0 +1,45 @@
+package builders
+
+import (
+	"context"
+	"time"
+
+	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/metrics"
+)
+
+// builderHeadRefresh - how often the latest block is queried for the builder metrics
+const builderHeadRefresh = 10 * time.Second
+
+// builderPosition - reports the position of a builder against the latest block
+type builderPosition struct {
+	builder     string
+	head        uint32
+	headUpdated time.Time
+}
+
+func newBuilderPosition(builder string) *builderPosition {
+	return &builderPosition{
+		builder: builder,
+	}
+}
+
+// set - report the next block number the builder will compute
+// NOTE the latest block is queried at most every builderHeadRefresh
+func (p *builderPosition) set(ctx context.Context, blockNumber uint32) {
+	metrics.BuilderBlockNumberGauge.WithLabelValues(p.builder).Set(float64(blockNumber))
+
+	if time.Since(p.headUpdated) >= builderHeadRefresh {
+		latestBlock, err := crud.GetBlockModel().SelectOne(ctx, 0)
+		if err == nil {
+			p.head = latestBlock.Number
+		}
+		p.headUpdated = time.Now()
+	}
+
+	behind := float64(0)
+	if p.head > blockNumber {
+		behind = float64(p.head - blockNumber)
+	}
+	metrics.BuilderBlocksBehindHeadGauge.WithLabelValues(p.builder).Set(behind)
+}
@@ -1,46 +1,64 @@
 package healthcheck
 
 import (
-	"net/http"
-	"net/url"
+	"context"
 	"time"
 
 	"github.com/geometry-labs/icon-blocks/config"
-	"go.uber.org/zap"
-
-	"github.com/InVisionApp/go-health/v2"
-	"github.com/InVisionApp/go-health/v2/checkers"
-	"github.com/InVisionApp/go-health/v2/handlers"
+	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/kafka"
+	"github.com/geometry-labs/icon-blocks/probes"
+	"github.com/geometry-labs/icon-blocks/redis"
+	"github.com/geometry-labs/icon-blocks/worker/status"
 )
 
 // Start - start health server
 func Start() {
-	// create a new health instance
-	h := health.New()
-
-	// create a couple of checks
-	blocksCheckerURL, _ := url.Parse("http://localhost:" + config.Config.Port + "/version")
-	blocksChecker, _ := checkers.NewHTTP(&checkers.HTTPConfig{
-		URL: blocksCheckerURL,
-	})
+	// Table lag, served on the health port
+	status.Start()
 
-	// Add the checks to the health instance
-	h.AddChecks([]*health.Config{
+	checks := []probes.Check{
+		{
+			Name:     "postgres",
+			Checker:  probes.CheckerFunc(crud.Ping),
+			Severity: probes.SeverityReadiness,
+		},
+		{
+			Name: "redis",
+			Checker: probes.CheckerFunc(func(ctx context.Context) error {
+				return redis.GetRedisClient().Ping(ctx)
+			}),
+			Severity: probes.SeverityReadiness,
+		},
+		{
+			Name:     "freshness",
+			Checker:  probes.Freshness(time.Duration(config.Config.HealthFreshnessMaxAge) * time.Second),
+			Severity: probes.SeverityReadiness,
+		},
 		{
-			Name:     "blocks-rest-check",
-			Checker:  blocksChecker,
-			Interval: time.Duration(config.Config.HealthPollingInterval) * time.Second,
-			Fatal:    true,
+			Name:     "table-lag",
+			Checker:  probes.CheckerFunc(status.Check),
+			Severity: probes.SeverityReadiness,
 		},
-	})
+	}
+
+	// Kafka
+	// NOTE routines, builders and file sources do not consume
+	if config.Config.OnlyRunAllRoutines == false && config.Config.WorkerSource == "kafka" {
+		checks = append(checks, probes.Check{
+			Name:     "kafka",
+			Checker:  probes.CheckerFunc(kafka.CheckBrokers),
+			Severity: probes.SeverityReadiness,
+		})
 
-	//  Start the healthcheck process
-	if err := h.Start(); err != nil {
-		zap.S().Fatalf("Unable to start healthcheck: %v", err)
+		if kafka.IsGroupConsumer() {
+			checks = append(checks, probes.Check{
+				Name:     "kafka-group",
+				Checker:  probes.CheckerFunc(kafka.CheckGroupMembership),
+				Severity: probes.SeverityReadiness,
+			})
+		}
 	}
 
-	// Define a healthcheck endpoint and use the built-in JSON handler
-	http.HandleFunc(config.Config.HealthPrefix, handlers.NewJSONHandlerFunc(h, nil))
-	go http.ListenAndServe(":"+config.Config.HealthPort, nil)
-	zap.S().Info("Started Healthcheck:", config.Config.HealthPort)
+	probes.Start(checks)
 }
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/kafka"
+)
+
+const jobsUsage = `Usage: worker jobs <command>
+
+Commands:
+  list                              list kafka jobs
+  get <job-id>                      show a kafka job and its partitions
+  create [-topics a,b,c] [-start-block n] [-stop-block m] <job-id>
+                                    create a kafka job up to the current high water marks,
+                                    or for blocks n to m
+  cancel <job-id>                   cancel the unfinished partitions of a kafka job
+`
+
+// runJobsCommand - manage kafka jobs from the command line, e.g. worker jobs list
+// Returns: exit code
+func runJobsCommand(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, jobsUsage)
+		return 2
+	}
+
+	ctx := context.Background()
+
+	var result interface{}
+	var err error
+	switch command, args := args[0], args[1:]; command {
+	case "list":
+		result, err = kafka.ListJobs(ctx)
+	case "get":
+		if len(args) != 1 {
+			fmt.Fprint(os.Stderr, jobsUsage)
+			return 2
+		}
+		result, err = kafka.GetJob(ctx, args[0])
+	case "create":
+		flags := flag.NewFlagSet("create", flag.ContinueOnError)
+		topics := flags.String("topics", "", "comma separated topics, defaults to the consumer topics")
+		startBlock := flags.Uint64("start-block", 0, "first block to consume, 0 for the oldest offsets")
+		stopBlock := flags.Uint64("stop-block", 0, "last block to consume, 0 for the high water marks")
+		if flags.Parse(args) != nil || flags.NArg() != 1 {
+			fmt.Fprint(os.Stderr, jobsUsage)
+			return 2
+		}
+
+		options := kafka.JobOptions{StartBlockNumber: *startBlock, StopBlockNumber: *stopBlock}
+		if *topics != "" {
+			options.Topics = strings.Split(*topics, ",")
+		}
+		result, err = kafka.CreateJob(ctx, flags.Arg(0), options)
+	case "cancel":
+		if len(args) != 1 {
+			fmt.Fprint(os.Stderr, jobsUsage)
+			return 2
+		}
+		result, err = kafka.CancelJob(ctx, args[0])
+	default:
+		fmt.Fprint(os.Stderr, jobsUsage)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err.Error())
+		return 1
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(result)
+
+	return 0
+}
+
+const backfillUsage = `Usage: worker backfill [-job-id id] <start-block> <end-block>
+
+Consume blocks start-block to end-block from the consumer topics, then exit.
+Rerunning the same range resumes the job.
+`
+
+// setupBackfill - create or resume the kafka job of a block range and run the worker as its consumer
+// e.g. worker backfill 1000 2000
+// Returns: exit code, 0 to continue starting the worker
+func setupBackfill(args []string) int {
+	flags := flag.NewFlagSet("backfill", flag.ContinueOnError)
+	jobID := flags.String("job-id", "", "job id, defaults to backfill-<start-block>-<end-block>")
+	if flags.Parse(args) != nil || flags.NArg() != 2 {
+		fmt.Fprint(os.Stderr, backfillUsage)
+		return 2
+	}
+
+	startBlock, err := strconv.ParseUint(flags.Arg(0), 10, 64)
+	if err != nil {
+		fmt.Fprint(os.Stderr, backfillUsage)
+		return 2
+	}
+	endBlock, err := strconv.ParseUint(flags.Arg(1), 10, 64)
+	if err != nil || endBlock < startBlock {
+		fmt.Fprint(os.Stderr, backfillUsage)
+		return 2
+	}
+	if *jobID == "" {
+		*jobID = fmt.Sprintf("backfill-%d-%d", startBlock, endBlock)
+	}
+	if config.Config.WorkerSource != "kafka" {
+		fmt.Fprintln(os.Stderr, "Error: backfill reads from kafka, WORKER_SOURCE is", config.Config.WorkerSource)
+		return 1
+	}
+
+	ctx := context.Background()
+
+	job, err := kafka.GetJob(ctx, *jobID)
+	if errors.Is(err, kafka.ErrJobNotFound) {
+		job, err = kafka.CreateJob(ctx, *jobID, kafka.JobOptions{
+			StartBlockNumber: startBlock,
+			StopBlockNumber:  endBlock,
+		})
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err.Error())
+		return 1
+	}
+
+	zap.S().Info("JOBID=", job.JobID, ",STATUS=", job.Status, " - Backfilling blocks ", startBlock, " to ", endBlock, ", ", job.Percent, "% done")
+
+	// Consume as the job worker
+	config.Config.ConsumerIsTail = true
+	config.Config.ConsumerIsPartitionConsumer = false
+	config.Config.OnlyRunAllRoutines = false
+	config.Config.ConsumerJobID = job.JobID
+
+	return 0
+}
@@ -2,14 +2,18 @@ package main
 
 import (
 	"log"
+	"os"
 
+	"github.com/geometry-labs/icon-blocks/admin"
 	"github.com/geometry-labs/icon-blocks/config"
 	"github.com/geometry-labs/icon-blocks/global"
-	"github.com/geometry-labs/icon-blocks/kafka"
 	"github.com/geometry-labs/icon-blocks/logging"
 	"github.com/geometry-labs/icon-blocks/metrics"
+	"github.com/geometry-labs/icon-blocks/tracing"
 	"github.com/geometry-labs/icon-blocks/worker/builders"
+	"github.com/geometry-labs/icon-blocks/worker/healthcheck"
 	"github.com/geometry-labs/icon-blocks/worker/routines"
+	"github.com/geometry-labs/icon-blocks/worker/sources"
 	"github.com/geometry-labs/icon-blocks/worker/transformers"
 )
 
@@ -19,9 +23,33 @@ func main() {
 	logging.Init()
 	log.Printf("Main: Starting logging with level %s", config.Config.LogLevel)
 
+	// Subcommands
+	// e.g. worker jobs list, worker backfill 1000 2000
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "jobs":
+			os.Exit(runJobsCommand(os.Args[2:]))
+		case "backfill":
+			if code := setupBackfill(os.Args[2:]); code != 0 {
+				os.Exit(code)
+			}
+		}
+	}
+
+	// Start tracing
+	tracing.Init()
+
 	// Start Prometheus client
 	metrics.Start()
 
+	// Start Admin server
+	// Go routine starts in function
+	admin.Start()
+
+	// Start Health server
+	// Go routine starts in function
+	healthcheck.Start()
+
 	// Feature flags
 	if config.Config.OnlyRunAllRoutines == true {
 		// Start routines
@@ -31,18 +59,24 @@ func main() {
 		builders.StartBlockTimeBuilder()
 		builders.StartBlockTransactionBuilder()
 
+		// Start producers
+		builders.StartEnrichedBlockProducer()
+
 		global.WaitShutdownSig()
 	}
 
-	// Start kafka consumer
+	// Start message source
 	// 1
-	kafka.StartWorkerConsumers()
+	source := sources.Start()
 
 	// Start transformers
 	// 2
-	transformers.StartBlocksTransformer()
-	transformers.StartTransactionsTransformer()
-	transformers.StartLogsTransformer()
+	transformers.StartBlocksTransformer(source)
+	transformers.StartTransactionsTransformer(source)
+	transformers.StartLogsTransformer(source)
 
 	global.WaitShutdownSig()
+
+	// Flush spans
+	tracing.Shutdown()
 }
@@ -1,11 +1,13 @@
 package routines
 
 import (
+	"context"
 	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/global"
 	"github.com/geometry-labs/icon-blocks/models"
 	"github.com/geometry-labs/icon-blocks/redis"
 )
@@ -13,10 +15,10 @@ import (
 func StartBlockCountRoutine() {
 
 	// routine every day
-	go blockCountRoutine(3600 * time.Second)
+	go blockCountRoutine(global.ShutdownContext(), 3600*time.Second)
 }
 
-func blockCountRoutine(duration time.Duration) {
+func blockCountRoutine(ctx context.Context, duration time.Duration) {
 
 	// Loop every duration
 	for {
@@ -26,7 +28,7 @@ func blockCountRoutine(duration time.Duration) {
 		/////////////
 
 		// Count
-		count, err := crud.GetBlockCountIndexModel().Count()
+		count, err := crud.GetBlockCountIndexModel().Count(ctx)
 		if err != nil {
 			// Postgres error
 			zap.S().Warn(err)
@@ -47,7 +49,7 @@ func blockCountRoutine(duration time.Duration) {
 			Type:  "block",
 			Count: uint64(count),
 		}
-		err = crud.GetBlockCountModel().UpsertOne(blockCount)
+		err = crud.GetBlockCountModel().UpsertOne(ctx, blockCount)
 
 		zap.S().Info("Completed routine, sleeping...")
 		time.Sleep(duration)
@@ -1,10 +1,12 @@
 package routines
 
 import (
+	"context"
 	"errors"
 	"time"
 
 	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/global"
 	"github.com/geometry-labs/icon-blocks/models"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -13,10 +15,10 @@ import (
 func StartBlockMissingRoutine() {
 
 	// routine every day
-	go blockMissingRoutine(3600 * time.Second)
+	go blockMissingRoutine(global.ShutdownContext(), 3600*time.Second)
 }
 
-func blockMissingRoutine(duration time.Duration) {
+func blockMissingRoutine(ctx context.Context, duration time.Duration) {
 
 	// Loop every duration
 	for {
@@ -24,12 +26,13 @@ func blockMissingRoutine(duration time.Duration) {
 		currentBlockNumber := 1
 
 		for {
-			block, err := crud.GetBlockModel().SelectOne(uint32(currentBlockNumber))
+			block, err := crud.GetBlockModel().SelectOne(ctx, uint32(currentBlockNumber))
 			if errors.Is(err, gorm.ErrRecordNotFound) || block.Hash == "" {
 				blockMissing := &models.BlockMissing{
 					Number: uint32(currentBlockNumber),
 				}
 
+				global.AddInFlight()
 				crud.GetBlockMissingModel().LoaderChannel <- blockMissing
 			} else if err != nil {
 				zap.S().Warn("Loader=BlockMissing Number=", currentBlockNumber, " Error=", err.Error(), " - Retrying...")
@@ -0,0 +1,219 @@
+package sources
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/global"
+	"github.com/geometry-labs/icon-blocks/kafka"
+	"github.com/geometry-labs/icon-blocks/models"
+)
+
+// fileSource - replays captured messages from files, one file per topic
+// .json and .jsonl files hold a json array or stream of raw models, e.g. fixtures/blocks_raw.json
+// .pb files hold length-delimited topic values, as consumed from kafka
+type fileSource struct {
+	files         map[string]string
+	topicChannels map[string]chan *sarama.ConsumerMessage
+}
+
+func newFileSource(files map[string]string) (*fileSource, error) {
+	source := &fileSource{
+		files:         files,
+		topicChannels: map[string]chan *sarama.ConsumerMessage{},
+	}
+
+	for _, topic := range consumerTopics() {
+		source.topicChannels[topic] = make(chan *sarama.ConsumerMessage)
+	}
+
+	for topic, path := range files {
+		if _, ok := source.topicChannels[topic]; !ok {
+			return nil, fmt.Errorf("%s is not a consumer topic", topic)
+		}
+
+		if _, err := fileFormat(path); err != nil {
+			return nil, err
+		}
+
+		if _, err := os.Stat(path); err != nil {
+			return nil, err
+		}
+	}
+
+	return source, nil
+}
+
+// Start - replay every file in its own go routine
+func (s *fileSource) Start() {
+	for topic, path := range s.files {
+		go s.replay(topic, path)
+	}
+}
+
+// TopicChannel - messages replayed for topic
+func (s *fileSource) TopicChannel(topic string) chan *sarama.ConsumerMessage {
+	return s.topicChannels[topic]
+}
+
+func (s *fileSource) replay(topic string, path string) {
+	file, err := os.Open(path)
+	if err != nil {
+		zap.S().Fatal("Source: unable to open ", path, ": ", err.Error())
+	}
+	defer file.Close()
+
+	zap.S().Info("Source: replaying ", path, " to topic ", topic)
+
+	offset := int64(0)
+	emit := func(value []byte) {
+		global.AddInFlight()
+		s.topicChannels[topic] <- &sarama.ConsumerMessage{
+			Topic:     topic,
+			Partition: 0,
+			Offset:    offset,
+			Value:     value,
+			Timestamp: time.Now(),
+		}
+		offset++
+	}
+
+	// NOTE fileFormat was checked when the source was created
+	format, _ := fileFormat(path)
+	switch format {
+	case "json":
+		err = readJSONRecords(file, func() proto.Message { return newTopicMessage(topic) }, emit)
+	case "pb":
+		err = readDelimitedRecords(file, emit)
+	}
+	if err != nil {
+		zap.S().Fatal("Source: unable to replay ", path, " record ", offset, ": ", err.Error())
+	}
+
+	zap.S().Info("Source: replayed ", offset, " messages from ", path)
+}
+
+// fileFormat - json or pb, from the file extension
+func fileFormat(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".jsonl":
+		return "json", nil
+	case ".pb":
+		return "pb", nil
+	}
+
+	return "", fmt.Errorf("%s: unknown file type, expected .json, .jsonl or .pb", path)
+}
+
+// newTopicMessage - raw model carried by topic
+func newTopicMessage(topic string) proto.Message {
+	switch topic {
+	case config.Config.ConsumerTopicBlocks:
+		return &models.BlockRaw{}
+	case config.Config.ConsumerTopicTransactions:
+		return &models.TransactionRaw{}
+	case config.Config.ConsumerTopicLogs:
+		return &models.LogRaw{}
+	}
+
+	return nil
+}
+
+// readJSONRecords - emit a topic value for every json record
+// NOTE records are either one json array or a stream of json objects
+func readJSONRecords(reader io.Reader, newMessage func() proto.Message, emit func([]byte)) error {
+	bufferedReader := bufio.NewReader(reader)
+
+	isArray, err := startsWithArray(bufferedReader)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bufferedReader)
+	if isArray {
+		// Opening bracket
+		if _, err := decoder.Token(); err != nil {
+			return err
+		}
+	}
+
+	unmarshalOptions := protojson.UnmarshalOptions{DiscardUnknown: true}
+	for decoder.More() {
+		record := json.RawMessage{}
+		if err := decoder.Decode(&record); err != nil {
+			return err
+		}
+
+		message := newMessage()
+		if err := unmarshalOptions.Unmarshal(record, message); err != nil {
+			return err
+		}
+
+		value, err := proto.Marshal(message)
+		if err != nil {
+			return err
+		}
+
+		emit(append(make([]byte, kafka.SchemaRegistryHeaderSize), value...))
+	}
+
+	return nil
+}
+
+// startsWithArray - true if the first non space byte is an opening bracket
+func startsWithArray(reader *bufio.Reader) (bool, error) {
+	for {
+		next, err := reader.Peek(1)
+		if err == io.EOF {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+
+		switch next[0] {
+		case ' ', '\t', '\r', '\n':
+			reader.ReadByte()
+			continue
+		}
+
+		return next[0] == '[', nil
+	}
+}
+
+// readDelimitedRecords - emit every value prefixed by its varint length
+func readDelimitedRecords(reader io.Reader, emit func([]byte)) error {
+	bufferedReader := bufio.NewReader(reader)
+
+	for {
+		size, err := binary.ReadUvarint(bufferedReader)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		value := make([]byte, size)
+		_, err = io.ReadFull(bufferedReader, value)
+		if errors.Is(err, io.EOF) {
+			return io.ErrUnexpectedEOF
+		} else if err != nil {
+			return err
+		}
+
+		emit(value)
+	}
+}
@@ -0,0 +1,109 @@
+package sources
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/kafka"
+	"github.com/geometry-labs/icon-blocks/models"
+)
+
+func newBlockRaw() proto.Message {
+	return &models.BlockRaw{}
+}
+
+func TestReadJSONRecordsArray(t *testing.T) {
+	assert := assert.New(t)
+
+	file, err := os.Open("../../fixtures/blocks_raw.json")
+	assert.Nil(err)
+	defer file.Close()
+
+	values := [][]byte{}
+	err = readJSONRecords(file, newBlockRaw, func(value []byte) {
+		values = append(values, value)
+	})
+	assert.Nil(err)
+	assert.NotEmpty(values)
+
+	// Values are framed like topic values
+	block := &models.BlockRaw{}
+	assert.Nil(proto.Unmarshal(values[0][kafka.SchemaRegistryHeaderSize:], block))
+	assert.Equal(uint32(33788433), block.Number)
+	assert.Equal(uint32(4), block.TransactionCount)
+	assert.Equal(uint64(1619815590946702), block.Timestamp)
+}
+
+func TestReadJSONRecordsStream(t *testing.T) {
+	assert := assert.New(t)
+
+	records := `
+{"number": 1, "hash": "a"}
+{"number": 2, "hash": "b", "unknown_field": true}
+`
+
+	numbers := []uint32{}
+	err := readJSONRecords(strings.NewReader(records), newBlockRaw, func(value []byte) {
+		block := &models.BlockRaw{}
+		assert.Nil(proto.Unmarshal(value[kafka.SchemaRegistryHeaderSize:], block))
+		numbers = append(numbers, block.Number)
+	})
+	assert.Nil(err)
+	assert.Equal([]uint32{1, 2}, numbers)
+
+	// Malformed record
+	err = readJSONRecords(strings.NewReader(`{"number": "one"}`), newBlockRaw, func([]byte) {})
+	assert.NotNil(err)
+}
+
+func TestReadDelimitedRecords(t *testing.T) {
+	assert := assert.New(t)
+
+	buffer := &bytes.Buffer{}
+	for _, value := range []string{"first", "", "third value"} {
+		size := make([]byte, binary.MaxVarintLen64)
+		buffer.Write(size[:binary.PutUvarint(size, uint64(len(value)))])
+		buffer.WriteString(value)
+	}
+
+	values := []string{}
+	err := readDelimitedRecords(bytes.NewReader(buffer.Bytes()), func(value []byte) {
+		values = append(values, string(value))
+	})
+	assert.Nil(err)
+	assert.Equal([]string{"first", "", "third value"}, values)
+
+	// Truncated value
+	truncated := buffer.Bytes()[:buffer.Len()-2]
+	err = readDelimitedRecords(bytes.NewReader(truncated), func([]byte) {})
+	assert.NotNil(err)
+}
+
+func TestNewFileSource(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.ConsumerTopicBlocks = "blocks"
+	config.Config.ConsumerTopicTransactions = "transactions"
+	config.Config.ConsumerTopicLogs = "logs"
+
+	source, err := newFileSource(map[string]string{"blocks": "../../fixtures/blocks_raw.json"})
+	assert.Nil(err)
+	assert.NotNil(source.TopicChannel("blocks"))
+	assert.NotNil(source.TopicChannel("logs"))
+
+	_, err = newFileSource(map[string]string{"unknown-topic": "../../fixtures/blocks_raw.json"})
+	assert.NotNil(err)
+
+	_, err = newFileSource(map[string]string{"blocks": "../../fixtures/fixtures.go"})
+	assert.NotNil(err)
+
+	_, err = newFileSource(map[string]string{"blocks": "../../fixtures/missing.json"})
+	assert.NotNil(err)
+}
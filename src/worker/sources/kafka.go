@@ -0,0 +1,20 @@
+package sources
+
+import (
+	"github.com/Shopify/sarama"
+
+	"github.com/geometry-labs/icon-blocks/kafka"
+)
+
+// kafkaSource - messages from the kafka consumers
+type kafkaSource struct{}
+
+// Start - start the consumers for the configured consumer mode
+func (kafkaSource) Start() {
+	kafka.StartWorkerConsumers()
+}
+
+// TopicChannel - messages consumed from topic
+func (kafkaSource) TopicChannel(topic string) chan *sarama.ConsumerMessage {
+	return kafka.KafkaTopicConsumer.TopicChannels[topic]
+}
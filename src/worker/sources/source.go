@@ -0,0 +1,53 @@
+package sources
+
+import (
+	"fmt"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-blocks/config"
+)
+
+// Source - messages read by the transformers, one channel per topic
+// NOTE messages keep the kafka message type so headers and offsets reach the transformers
+type Source interface {
+	// Start - start reading messages in go routines
+	Start()
+
+	// TopicChannel - messages of a consumer topic
+	TopicChannel(topic string) chan *sarama.ConsumerMessage
+}
+
+// Start - start the source set by WORKER_SOURCE
+func Start() Source {
+	source, err := newSource(config.Config.WorkerSource)
+	if err != nil {
+		zap.S().Fatal("Source: ", err.Error())
+	}
+
+	zap.S().Info("Source: reading messages from ", config.Config.WorkerSource)
+	source.Start()
+
+	return source
+}
+
+func newSource(name string) (Source, error) {
+	switch name {
+	case "kafka":
+		return kafkaSource{}, nil
+	case "file":
+		return newFileSource(config.Config.SourceFiles)
+	}
+
+	return nil, fmt.Errorf("unknown source %q", name)
+}
+
+// consumerTopics - topics read by the transformers
+func consumerTopics() []string {
+	return []string{
+		config.Config.ConsumerTopicBlocks,
+		config.Config.ConsumerTopicTransactions,
+		config.Config.ConsumerTopicLogs,
+	}
+}
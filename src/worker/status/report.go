@@ -0,0 +1,80 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Report statuses
+const (
+	statusOK     = "ok"
+	statusFailed = "failed"
+)
+
+// report - lag of every derived table, served on /status
+type report struct {
+	Status    string                  `json:"status"`
+	Head      headReport              `json:"head"`
+	Tables    map[string]*tableReport `json:"tables"`
+	UpdatedAt time.Time               `json:"updated_at"`
+}
+
+// headReport - chain head the tables are compared to
+type headReport struct {
+	Number    uint32 `json:"number"`
+	Timestamp uint64 `json:"timestamp"`
+}
+
+// tableReport - position of one derived table
+type tableReport struct {
+	Number           uint32  `json:"number"`
+	BlocksBehind     uint32  `json:"blocks_behind"`
+	SecondsBehind    float64 `json:"seconds_behind"`
+	MaxBlocksBehind  int     `json:"max_blocks_behind,omitempty"`
+	MaxSecondsBehind int     `json:"max_seconds_behind,omitempty"`
+	Error            string  `json:"error,omitempty"`
+}
+
+// check - error if the table exceeds one of its thresholds
+// NOTE a table without thresholds never fails
+func (t *tableReport) check() error {
+	if t.MaxBlocksBehind <= 0 && t.MaxSecondsBehind <= 0 {
+		return nil
+	}
+
+	if t.Error != "" {
+		return fmt.Errorf("position unknown: %s", t.Error)
+	}
+	if t.MaxBlocksBehind > 0 && t.BlocksBehind > uint32(t.MaxBlocksBehind) {
+		return fmt.Errorf("%d blocks behind, max %d", t.BlocksBehind, t.MaxBlocksBehind)
+	}
+	if t.MaxSecondsBehind > 0 && t.SecondsBehind > float64(t.MaxSecondsBehind) {
+		return fmt.Errorf("%.0f seconds behind, max %d", t.SecondsBehind, t.MaxSecondsBehind)
+	}
+
+	return nil
+}
+
+// check - error listing every table exceeding its thresholds
+func (r *report) check() error {
+	names := make([]string, 0, len(r.Tables))
+	for name := range r.Tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	failures := []string{}
+	for _, name := range names {
+		if err := r.Tables[name].check(); err != nil {
+			failures = append(failures, name+": "+err.Error())
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%s", strings.Join(failures, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package status
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableReportCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	// No thresholds
+	table := &tableReport{BlocksBehind: 1000000, SecondsBehind: 1000000}
+	assert.Nil(table.check())
+
+	// Within thresholds
+	table = &tableReport{BlocksBehind: 10, SecondsBehind: 20, MaxBlocksBehind: 10, MaxSecondsBehind: 60}
+	assert.Nil(table.check())
+
+	// Blocks exceeded
+	table = &tableReport{BlocksBehind: 11, SecondsBehind: 20, MaxBlocksBehind: 10, MaxSecondsBehind: 60}
+	assert.EqualError(table.check(), "11 blocks behind, max 10")
+
+	// Seconds exceeded
+	table = &tableReport{BlocksBehind: 1, SecondsBehind: 61, MaxSecondsBehind: 60}
+	assert.EqualError(table.check(), "61 seconds behind, max 60")
+
+	// Unknown position with a threshold
+	table = &tableReport{Error: "no rows", MaxBlocksBehind: 10}
+	assert.EqualError(table.check(), "position unknown: no rows")
+
+	// Unknown position without a threshold
+	table = &tableReport{Error: "no rows"}
+	assert.Nil(table.check())
+}
+
+func TestReportCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &report{
+		Tables: map[string]*tableReport{
+			"blocks":      {BlocksBehind: 1, MaxBlocksBehind: 10},
+			"block_times": {BlocksBehind: 1},
+		},
+	}
+	assert.Nil(r.check())
+
+	// Every failing table, sorted by name
+	r.Tables["blocks"].BlocksBehind = 20
+	r.Tables["block_times"] = &tableReport{BlocksBehind: 30, MaxBlocksBehind: 5}
+	assert.EqualError(r.check(), "block_times: 30 blocks behind, max 5; blocks: 20 blocks behind, max 10")
+}
@@ -0,0 +1,230 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-blocks/config"
+	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/global"
+	"github.com/geometry-labs/icon-blocks/metrics"
+	"github.com/geometry-labs/icon-blocks/redis"
+	"github.com/geometry-labs/icon-blocks/worker/builders"
+)
+
+// tablePosition - highest block number reached by a derived table
+type tablePosition struct {
+	table    string
+	position func(ctx context.Context) (uint32, error)
+}
+
+// tablePositions - every derived table built by the worker
+func tablePositions() []tablePosition {
+	positions := []tablePosition{
+		{
+			table: "blocks",
+			position: func(ctx context.Context) (uint32, error) {
+				block, err := crud.GetBlockModel().SelectOne(ctx, 0)
+				return block.Number, err
+			},
+		},
+		{
+			table: "block_times",
+			position: func(ctx context.Context) (uint32, error) {
+				blockTime, err := crud.GetBlockTimeModel().SelectLatest(ctx)
+				return blockTime.Number, err
+			},
+		},
+	}
+
+	// Transaction amounts and fees on blocks
+	for _, suffix := range builders.BlockTransactionBuilderCounters {
+		countKey := builders.BlockTransactionBuilderCountKey(suffix)
+
+		positions = append(positions, tablePosition{
+			table: "block_transaction_enrichment" + suffix,
+			position: func(ctx context.Context) (uint32, error) {
+				// NOTE counter is the next block the builder will compute
+				count, err := redis.GetRedisClient().GetCount(countKey)
+				if err != nil {
+					return 0, err
+				}
+				if count <= 1 {
+					return 0, errors.New("builder has not started")
+				}
+
+				return uint32(count - 1), nil
+			},
+		})
+	}
+
+	return positions
+}
+
+// chainHead - highest block seen on the blocks topic by this process
+var chainHead struct {
+	sync.Mutex
+	number    uint32
+	timestamp uint64
+}
+
+// SetChainHead - report a block consumed from the blocks topic
+// NOTE processes not consuming blocks use the latest block in postgres as the head
+func SetChainHead(number uint32, timestamp uint64) {
+	chainHead.Lock()
+	defer chainHead.Unlock()
+
+	if number > chainHead.number {
+		chainHead.number = number
+		chainHead.timestamp = timestamp
+	}
+}
+
+// latest - last computed report
+var latest struct {
+	sync.RWMutex
+	report *report
+}
+
+// Start - compute the table lag every polling interval and serve it on the health port
+func Start() {
+	http.HandleFunc(config.Config.StatusPrefix, handler)
+
+	go func() {
+		ctx := global.ShutdownContext()
+		interval := time.Duration(config.Config.HealthPollingInterval) * time.Second
+
+		for {
+			r := compute(ctx)
+
+			latest.Lock()
+			latest.report = r
+			latest.Unlock()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+		}
+	}()
+
+	zap.S().Info("Started status:", config.Config.StatusPrefix)
+}
+
+// Check - readiness check, failing when a table exceeds its thresholds
+func Check(ctx context.Context) error {
+	latest.RLock()
+	r := latest.report
+	latest.RUnlock()
+
+	if r == nil {
+		return errors.New("status not computed yet")
+	}
+
+	// NOTE a stuck computation must not keep reporting old positions
+	maxAge := 3 * time.Duration(config.Config.HealthPollingInterval) * time.Second
+	if age := time.Since(r.UpdatedAt); age > maxAge {
+		return fmt.Errorf("status last updated %s ago", age.Round(time.Second))
+	}
+
+	return r.check()
+}
+
+func compute(ctx context.Context) *report {
+	r := &report{
+		Status: statusOK,
+		Tables: map[string]*tableReport{},
+	}
+
+	// Chain head
+	latestBlock, err := crud.GetBlockModel().SelectOne(ctx, 0)
+	if err == nil {
+		r.Head.Number = latestBlock.Number
+		r.Head.Timestamp = latestBlock.Timestamp
+	}
+	chainHead.Lock()
+	if chainHead.number > r.Head.Number {
+		r.Head.Number = chainHead.number
+		r.Head.Timestamp = chainHead.timestamp
+	}
+	chainHead.Unlock()
+
+	for _, position := range tablePositions() {
+		table := &tableReport{
+			MaxBlocksBehind:  config.Config.StatusMaxBlocksBehind[position.table],
+			MaxSecondsBehind: config.Config.StatusMaxSecondsBehind[position.table],
+		}
+		r.Tables[position.table] = table
+
+		err := computeTable(ctx, table, r.Head, position)
+		if err != nil {
+			table.Error = err.Error()
+			zap.S().Debug("Status: table=", position.table, " error=", err.Error())
+		} else {
+			metrics.TableBlocksBehindHeadGauge.WithLabelValues(position.table).Set(float64(table.BlocksBehind))
+			metrics.TableSecondsBehindHeadGauge.WithLabelValues(position.table).Set(table.SecondsBehind)
+		}
+
+		if table.check() != nil {
+			r.Status = statusFailed
+		}
+	}
+
+	r.UpdatedAt = time.Now()
+	return r
+}
+
+// computeTable - fill the position of a table against the head
+// NOTE seconds behind is the age of the block the table has reached
+func computeTable(ctx context.Context, table *tableReport, head headReport, position tablePosition) error {
+	number, err := position.position(ctx)
+	if err != nil {
+		return err
+	}
+	if number == 0 {
+		return errors.New("no rows")
+	}
+	table.Number = number
+
+	if head.Number > number {
+		table.BlocksBehind = head.Number - number
+	}
+
+	block, err := crud.GetBlockModel().SelectOne(ctx, number)
+	if err != nil {
+		return err
+	}
+
+	// NOTE block timestamps are in microseconds
+	age := time.Since(time.Unix(0, int64(block.Timestamp)*int64(time.Microsecond)))
+	table.SecondsBehind = age.Seconds()
+
+	return nil
+}
+
+// handler - latest report as json, 503 if a threshold is exceeded
+func handler(w http.ResponseWriter, r *http.Request) {
+	latest.RLock()
+	body := latest.report
+	latest.RUnlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if body == nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"status":"pending"}`))
+		return
+	}
+
+	if body.Status != statusOK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(body)
+}
@@ -1,26 +1,30 @@
 package transformers
 
 import (
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/geometry-labs/icon-blocks/config"
 	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/global"
 	"github.com/geometry-labs/icon-blocks/kafka"
 	"github.com/geometry-labs/icon-blocks/metrics"
 	"github.com/geometry-labs/icon-blocks/models"
+	"github.com/geometry-labs/icon-blocks/worker/sources"
+	"github.com/geometry-labs/icon-blocks/worker/status"
 )
 
 // StartBlocksTransformer - start block transformer go routine
-func StartBlocksTransformer() {
-	go blocksTransformer()
+func StartBlocksTransformer(source sources.Source) {
+	go blocksTransformer(source)
 }
 
-func blocksTransformer() {
+func blocksTransformer(source sources.Source) {
 	consumerTopicNameBlocks := config.Config.ConsumerTopicBlocks
 
 	// Input channels
-	consumerTopicChanBlocks := kafka.KafkaTopicConsumer.TopicChannels[consumerTopicNameBlocks]
+	consumerTopicChanBlocks := source.TopicChannel(consumerTopicNameBlocks)
 
 	// Output channels
 	blockLoaderChan := crud.GetBlockModel().LoaderChannel
@@ -35,11 +39,15 @@ func blocksTransformer() {
 		///////////////////
 
 		consumerTopicMsg := <-consumerTopicChanBlocks
+		span := startTransformSpan("blocks", consumerTopicMsg)
+
 		blockRaw, err := convertToBlockRawProtoBuf(consumerTopicMsg.Value)
 		zap.S().Debug("Blocks Transformer: Processing block #", blockRaw.Number)
 		if err != nil {
 			zap.S().Fatal("Blocks transformer: Unable to proceed cannot convert kafka msg value to BlockRaw, err: ", err.Error())
 		}
+		span.SetAttributes(attribute.Int64("block.number", int64(blockRaw.Number)))
+		metrics.TransformerMessagesProcessedCounter.WithLabelValues("blocks").Inc()
 
 		/////////////
 		// Loaders //
@@ -47,14 +55,17 @@ func blocksTransformer() {
 
 		// Load to: blocks
 		block := transformBlockRawToBlock(blockRaw)
+		global.AddInFlight()
 		blockLoaderChan <- block
 
 		// Load to: blocks
 		blockWebsocket := transformBlockToBlockWS(block)
+		global.AddInFlight()
 		blockWebsocketLoaderChan <- blockWebsocket
 
 		// Load to: block_counts
 		blockCount := transformBlockToBlockCount(block)
+		global.AddInFlight()
 		blockCountLoaderChan <- blockCount
 
 		/////////////
@@ -63,12 +74,18 @@ func blocksTransformer() {
 
 		// max_block_number_blocks_raw
 		metrics.MaxBlockNumberBlocksRawGauge.Set(float64(blockRaw.Number))
+
+		// Chain head for the table lag status
+		status.SetChainHead(blockRaw.Number, blockRaw.Timestamp)
+
+		global.DoneInFlight()
+		span.End()
 	}
 }
 
 func convertToBlockRawProtoBuf(value []byte) (*models.BlockRaw, error) {
 	block := models.BlockRaw{}
-	err := proto.Unmarshal(value[6:], &block)
+	err := proto.Unmarshal(value[kafka.SchemaRegistryHeaderSize:], &block)
 	if err != nil {
 		zap.S().Error("Error: ", err.Error())
 	}
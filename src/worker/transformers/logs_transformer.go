@@ -4,29 +4,42 @@ import (
 	"encoding/json"
 	"strings"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/geometry-labs/icon-blocks/config"
 	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/global"
 	"github.com/geometry-labs/icon-blocks/kafka"
 	"github.com/geometry-labs/icon-blocks/metrics"
 	"github.com/geometry-labs/icon-blocks/models"
+	"github.com/geometry-labs/icon-blocks/redis"
+	"github.com/geometry-labs/icon-blocks/worker/builders"
+	"github.com/geometry-labs/icon-blocks/worker/sources"
 )
 
 // StartLogsTransformer - start block transformer go routine
-func StartLogsTransformer() {
-	go logsTransformer()
+func StartLogsTransformer(source sources.Source) {
+	go logsTransformer(source)
 }
 
-func logsTransformer() {
+func logsTransformer(source sources.Source) {
 	consumerTopicNameLogs := config.Config.ConsumerTopicLogs
 
 	// Input channels
-	consumerTopicChanLogs := kafka.KafkaTopicConsumer.TopicChannels[consumerTopicNameLogs]
+	consumerTopicChanLogs := source.TopicChannel(consumerTopicNameLogs)
 
 	// Output channels
 	blockInternalTransactionChan := crud.GetBlockInternalTransactionModel().LoaderChannel
+	blockInternalTransactionWebsocketChan := crud.GetBlockInternalTransactionWebsocketIndexModel().LoaderChannel
+
+	// Logs head
+	// NOTE only moves forward, logs consumed again after a restart do not lower it
+	logsHead, err := redis.GetRedisClient().GetCount(builders.LogsHeadCountKey)
+	if err != nil {
+		zap.S().Warn("Logs Transformer: unable to get logs head: ", err.Error())
+	}
 
 	zap.S().Debug("Logs Transformer: started working")
 	for {
@@ -36,11 +49,26 @@ func logsTransformer() {
 		///////////////////
 
 		consumerTopicMsg := <-consumerTopicChanLogs
+		span := startTransformSpan("logs", consumerTopicMsg)
+
 		logRaw, err := convertBytesToLogRawProtoBuf(consumerTopicMsg.Value)
 		zap.S().Debug("Logs Transformer: Processing block #", logRaw.BlockNumber)
 		if err != nil {
 			zap.S().Fatal("Unable to proceed cannot convert kafka msg value to Log, err: ", err.Error())
 		}
+		span.SetAttributes(attribute.Int64("block.number", int64(logRaw.BlockNumber)))
+		metrics.TransformerMessagesProcessedCounter.WithLabelValues("logs").Inc()
+
+		// Set logs head in redis
+		if int64(logRaw.BlockNumber) > logsHead {
+			err = redis.GetRedisClient().SetCount(builders.LogsHeadCountKey, int64(logRaw.BlockNumber))
+			if err != nil {
+				// Redis error
+				zap.S().Warn("Logs Transformer: unable to set logs head: ", err.Error())
+			} else {
+				logsHead = int64(logRaw.BlockNumber)
+			}
+		}
 
 		/////////////
 		// Loaders //
@@ -51,24 +79,35 @@ func logsTransformer() {
 		blockInternalTransaction := transformLogRawToBlockInternalTransaction(logRaw)
 		if blockInternalTransaction == nil {
 			// Not an internal transaction
+			global.DoneInFlight()
+			span.End()
 			continue
 		}
 
 		// Load to Postgres
+		global.AddInFlight()
 		blockInternalTransactionChan <- blockInternalTransaction
 
+		// Load to websocket index
+		blockInternalTransactionWebsocket := transformLogRawToBlockInternalTransaction(logRaw)
+		global.AddInFlight()
+		blockInternalTransactionWebsocketChan <- blockInternalTransactionWebsocket
+
 		/////////////
 		// Metrics //
 		/////////////
 
 		// max_block_number_logs_raw
 		metrics.MaxBlockNumberLogsRawGauge.Set(float64(logRaw.BlockNumber))
+
+		global.DoneInFlight()
+		span.End()
 	}
 }
 
 func convertBytesToLogRawProtoBuf(value []byte) (*models.LogRaw, error) {
 	log := models.LogRaw{}
-	err := proto.Unmarshal(value[6:], &log)
+	err := proto.Unmarshal(value[kafka.SchemaRegistryHeaderSize:], &log)
 	if err != nil {
 		zap.S().Error("Error: ", err.Error())
 	}
@@ -0,0 +1,19 @@
+package transformers
+
+import (
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/geometry-labs/icon-blocks/global"
+	"github.com/geometry-labs/icon-blocks/tracing"
+)
+
+// startTransformSpan - span for one kafka message, child of its consume span
+func startTransformSpan(transformer string, msg *sarama.ConsumerMessage) trace.Span {
+	_, span := tracing.Start(
+		tracing.ExtractKafkaHeaders(global.ShutdownContext(), msg),
+		"transform."+transformer,
+	)
+
+	return span
+}
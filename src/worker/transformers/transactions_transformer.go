@@ -4,30 +4,35 @@ import (
 	"fmt"
 	"math/big"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/geometry-labs/icon-blocks/config"
 	"github.com/geometry-labs/icon-blocks/crud"
+	"github.com/geometry-labs/icon-blocks/global"
 	"github.com/geometry-labs/icon-blocks/kafka"
 	"github.com/geometry-labs/icon-blocks/metrics"
 	"github.com/geometry-labs/icon-blocks/models"
+	"github.com/geometry-labs/icon-blocks/worker/sources"
 )
 
 // StartTransactionsTransformer - start block transformer go routine
-func StartTransactionsTransformer() {
-	go transactionsTransformer()
+func StartTransactionsTransformer(source sources.Source) {
+	go transactionsTransformer(source)
 }
 
-func transactionsTransformer() {
+func transactionsTransformer(source sources.Source) {
 	consumerTopicNameTransactions := config.Config.ConsumerTopicTransactions
 
 	// Input channels
-	consumerTopicChanTransactions := kafka.KafkaTopicConsumer.TopicChannels[consumerTopicNameTransactions]
+	consumerTopicChanTransactions := source.TopicChannel(consumerTopicNameTransactions)
 
 	// Output channels
 	blockTransactionLoaderChan := crud.GetBlockTransactionModel().LoaderChannel
+	blockTransactionWebsocketLoaderChan := crud.GetBlockTransactionWebsocketIndexModel().LoaderChannel
 	blockFailedTransactionLoaderChan := crud.GetBlockFailedTransactionModel().LoaderChannel
+	blockFailedTransactionWebsocketLoaderChan := crud.GetBlockFailedTransactionWebsocketIndexModel().LoaderChannel
 
 	zap.S().Debug("Transactions Transformer: started working")
 	for {
@@ -37,11 +42,15 @@ func transactionsTransformer() {
 		///////////////////
 
 		consumerTopicMsg := <-consumerTopicChanTransactions
+		span := startTransformSpan("transactions", consumerTopicMsg)
+
 		transactionRaw, err := convertBytesToTransactionRawProtoBuf(consumerTopicMsg.Value)
 		zap.S().Debug("Transactions Transformer: Processing block #", transactionRaw.BlockNumber)
 		if err != nil {
 			zap.S().Fatal("Unable to proceed cannot convert kafka msg value to TransactionRaw, err: ", err.Error())
 		}
+		span.SetAttributes(attribute.Int64("block.number", int64(transactionRaw.BlockNumber)))
+		metrics.TransformerMessagesProcessedCounter.WithLabelValues("transactions").Inc()
 
 		/////////////
 		// Loaders //
@@ -49,28 +58,45 @@ func transactionsTransformer() {
 
 		// Loads to: block_transactions
 		blockTransaction := transformTransactionRawToBlockTransaction(transactionRaw)
+		global.AddInFlight()
 		blockTransactionLoaderChan <- blockTransaction
 
+		// Loads to: block_transaction_websocket_indices
+		blockTransactionWebsocket := transformTransactionRawToBlockTransaction(transactionRaw)
+		global.AddInFlight()
+		blockTransactionWebsocketLoaderChan <- blockTransactionWebsocket
+
 		// Loads to: block_failed_transactions
 		blockFailedTransaction := transformTransactionRawToBlockFailedTransaction(transactionRaw)
 		if blockFailedTransaction == nil {
 			// Not a failed transaction
+			global.DoneInFlight()
+			span.End()
 			continue
 		}
+		global.AddInFlight()
 		blockFailedTransactionLoaderChan <- blockFailedTransaction
 
+		// Loads to: block_failed_transaction_websocket_indices
+		blockFailedTransactionWebsocket := transformTransactionRawToBlockFailedTransaction(transactionRaw)
+		global.AddInFlight()
+		blockFailedTransactionWebsocketLoaderChan <- blockFailedTransactionWebsocket
+
 		/////////////
 		// Metrics //
 		/////////////
 
 		// max_block_number_transactions_raw
 		metrics.MaxBlockNumberTransactionsRawGauge.Set(float64(transactionRaw.BlockNumber))
+
+		global.DoneInFlight()
+		span.End()
 	}
 }
 
 func convertBytesToTransactionRawProtoBuf(value []byte) (*models.TransactionRaw, error) {
 	tx := models.TransactionRaw{}
-	err := proto.Unmarshal(value[6:], &tx)
+	err := proto.Unmarshal(value[kafka.SchemaRegistryHeaderSize:], &tx)
 	if err != nil {
 		zap.S().Error("Error: ", err.Error())
 	}
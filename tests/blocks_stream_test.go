@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Stream resume test
+func TestBlocksEndpointStream(t *testing.T) {
+	assert := assert.New(t)
+
+	blocksServiceURL := os.Getenv("BLOCKS_SERVICE_URL")
+	if blocksServiceURL == "" {
+		blocksServiceURL = "http://localhost:8000"
+	}
+	blocksServiceRestPrefx := os.Getenv("BLOCKS_SERVICE_REST_PREFIX")
+	if blocksServiceRestPrefx == "" {
+		blocksServiceRestPrefx = "/api/v1"
+	}
+
+	// Get latest blocks
+	resp, err := http.Get(blocksServiceURL + blocksServiceRestPrefx + "/blocks?limit=2")
+	assert.Equal(nil, err)
+	assert.Equal(200, resp.StatusCode)
+
+	defer resp.Body.Close()
+
+	bytes, err := ioutil.ReadAll(resp.Body)
+	assert.Equal(nil, err)
+
+	bodyMap := make([]interface{}, 0)
+	err = json.Unmarshal(bytes, &bodyMap)
+	assert.Equal(nil, err)
+	assert.Equal(2, len(bodyMap))
+
+	// Resume from the second latest block
+	lastEventID := strconv.FormatUint(uint64(bodyMap[1].(map[string]interface{})["number"].(float64)), 10)
+	expectedEventID := strconv.FormatUint(uint64(bodyMap[0].(map[string]interface{})["number"].(float64)), 10)
+
+	req, err := http.NewRequest("GET", blocksServiceURL+blocksServiceRestPrefx+"/blocks/stream", nil)
+	assert.Equal(nil, err)
+	req.Header.Set("Last-Event-ID", lastEventID)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err = client.Do(req)
+	assert.Equal(nil, err)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal(true, strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream"))
+
+	defer resp.Body.Close()
+
+	// First event is the replayed latest block
+	reader := bufio.NewReader(resp.Body)
+	for {
+		line, err := reader.ReadString('\n')
+		assert.Equal(nil, err)
+		if err != nil {
+			break
+		}
+
+		if strings.HasPrefix(line, "id: ") {
+			assert.Equal(expectedEventID, strings.TrimSpace(strings.TrimPrefix(line, "id: ")))
+			break
+		}
+	}
+}